@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/internal/jwtkeys"
 	"github.com/ariefzainuri96/go-logstream/internal/service"
 	"github.com/go-playground/validator/v10"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -16,12 +17,29 @@ import (
 type Config struct {
 	HTTPPort    int
 	ShutdownTTL time.Duration
+	// e.g. https://logstream.example.com, used for absolute links in feeds.
+	// When empty it is taken from the request.
+	PublicBaseURL string
+	// read the client IP from X-Forwarded-For, only behind a proxy that sets it
+	TrustProxyHeaders bool
 }
 
 type Application struct {
 	Config    Config
 	Service   service.Service
 	Validator *validator.Validate
+	// signs and verifies the JWTs, its public keys are served at /.well-known/jwks.json
+	Keys   *jwtkeys.KeyManager
+	Logger *zap.Logger
+}
+
+// authentication is middleware.Authentication, accepting project API keys as well
+func (app *Application) authentication(next http.Handler) http.Handler {
+	return middleware.Authentication(middleware.Authenticator{
+		ApiKeys:        app.Service.IProjectApiKey.AuthenticateApiKey,
+		ParseToken:     app.Keys.Parse,
+		IsTokenRevoked: app.Service.IAuth.IsTokenRevoked,
+	}, next)
 }
 
 func (app *Application) RunServer(ctx context.Context, cfg Config, logger *zap.Logger) error {
@@ -35,11 +53,17 @@ func (app *Application) RunServer(ctx context.Context, cfg Config, logger *zap.L
 
 	mux.Handle("/v1/auth/", http.StripPrefix("/v1/auth", app.AuthController()))
 
-	mux.Handle("/v1/projects/", middleware.Authentication(http.StripPrefix("/v1/projects", app.ProjectController())))
+	mux.Handle("/v1/projects/", app.authentication(http.StripPrefix("/v1/projects", app.ProjectController())))
+
+	mux.Handle("/v1/orgs/", app.authentication(http.StripPrefix("/v1/orgs", app.OrganizationController())))
+
+	mux.Handle("/v1/posts/", app.authentication(http.StripPrefix("/v1/posts", app.PostController())))
+
+	mux.Handle("/v1/public/", http.StripPrefix("/v1/public", app.PublicController()))
 
-	mux.Handle("/v1/posts/", middleware.Authentication(http.StripPrefix("/v1/posts", app.PostController())))
+	mux.Handle("/changelog/", http.StripPrefix("/changelog", app.ChangelogController()))
 
-	mux.Handle("v1/public/", http.StripPrefix("/v1/public", app.PublicController()))
+	mux.HandleFunc("GET /.well-known/jwks.json", app.jwks)
 
 	mux.Handle("/v1/swagger/", httpSwagger.Handler(
 		httpSwagger.URL("doc.json"),
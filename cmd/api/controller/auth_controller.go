@@ -2,22 +2,32 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/loginlimit"
+	"github.com/ariefzainuri96/go-logstream/internal/service"
+	"gorm.io/gorm"
 )
 
 // @Summary      Login
-// @Description  Perform login
+// @Description  Perform login, repeated failures of an email or IP get 429 with Retry-After. Token is a short lived access token, exchange refresh_token for a new pair at /auth/refresh before it expires. Users with 2FA get mfa_required and an mfa_token instead of the tokens, send it with a code to /auth/2fa/verify within 5 minutes
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request	body	  request.LoginRequest	true "Login request"
 // @Success      200  		{object}  response.LoginResponse
 // @Failure      400  		{object}  response.BaseResponse
+// @Failure      403  		{object}  response.BaseResponse
 // @Failure      404  		{object}  response.BaseResponse
+// @Failure      429  		{object}  response.BaseResponse
 // @Router       /auth/login	[post]
 func (app *Application) login(w http.ResponseWriter, r *http.Request) {
 	var data request.LoginRequest
@@ -35,28 +45,77 @@ func (app *Application) login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, token, err := app.Service.IAuth.Login(r.Context(), data)
+	ctx := middleware.WithClientIP(r.Context(), middleware.ClientIP(r, app.Config.TrustProxyHeaders))
+
+	user, tokens, err := app.Service.ILogin.Login(ctx, data)
+
+	if respondThrottled(w, err) {
+		return
+	}
+
+	if errors.Is(err, service.ErrEmailNotVerified) {
+		utils.RespondError(w, http.StatusForbidden, "Please verify your email first, check your inbox or resend the verification email")
+		return
+	}
 
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Invalid email/password!")
 		return
 	}
 
+	respondLogin(w, user, tokens)
+}
+
+// respondLogin writes the tokens of a login, or the MFA challenge of a user with 2FA.
+func respondLogin(w http.ResponseWriter, user entity.User, tokens entity.AuthTokens) {
+	if tokens.MfaToken != "" {
+		utils.WriteJSON(w, http.StatusOK, response.LoginResponse{
+			BaseResponse: response.BaseResponse{
+				Status:  http.StatusOK,
+				Message: "2FA code required",
+			},
+			Data: response.LoginData{
+				ID:          int(user.ID),
+				Email:       user.Email,
+				MfaRequired: true,
+				MfaToken:    tokens.MfaToken,
+			},
+		})
+		return
+	}
+
 	utils.WriteJSON(w, http.StatusOK, response.LoginResponse{
 		BaseResponse: response.BaseResponse{
 			Status:  http.StatusOK,
 			Message: "Success",
 		},
 		Data: response.LoginData{
-			ID:    int(user.ID),
-			Token: token,
-			Email: user.Email,
+			ID:           int(user.ID),
+			Token:        tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
+			Email:        user.Email,
 		},
 	})
 }
 
+// respondThrottled responds 429 with Retry-After when err is a
+// *loginlimit.ThrottledError, and reports whether it did.
+func respondThrottled(w http.ResponseWriter, err error) bool {
+	var throttled *loginlimit.ThrottledError
+
+	if !errors.As(err, &throttled) {
+		return false
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	utils.RespondError(w, http.StatusTooManyRequests, "Too many failed logins, please retry later")
+
+	return true
+}
+
 // @Summary      Register
-// @Description  Perform register
+// @Description  Perform register, a verification link is emailed to the address
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -95,11 +154,286 @@ func (app *Application) register(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// @Summary      Refresh Token
+// @Description  Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once, using it again revokes every token of the login
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request		body	  request.RefreshTokenRequest	true "Refresh Token request"
+// @Success      200  			{object}  response.TokenResponse
+// @Failure      400  			{object}  response.BaseResponse
+// @Failure      401  			{object}  response.BaseResponse
+// @Router       /auth/refresh	[post]
+func (app *Application) refresh(w http.ResponseWriter, r *http.Request) {
+	var data request.RefreshTokenRequest
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tokens, err := app.Service.IAuth.Refresh(r.Context(), data.RefreshToken)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusUnauthorized, "Invalid refresh token, please re login!")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.TokenResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success",
+		},
+		Data: response.TokenData{
+			Token:        tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
+		},
+	})
+}
+
+// @Summary      Logout
+// @Description  Revoke the refresh token and every token of its login, the access tokens issued with them are rejected right away
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request		body	  request.RefreshTokenRequest	true "Logout request"
+// @Success      200  			{object}  response.BaseResponse
+// @Failure      400  			{object}  response.BaseResponse
+// @Failure      401  			{object}  response.BaseResponse
+// @Router       /auth/logout	[post]
+func (app *Application) logout(w http.ResponseWriter, r *http.Request) {
+	var data request.RefreshTokenRequest
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = app.Service.IAuth.Logout(r.Context(), data.RefreshToken)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusUnauthorized, "Invalid refresh token")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "Success logout",
+	})
+}
+
+// @Summary      Forgot Password
+// @Description  Email a one-time password reset token, valid for an hour. The response is the same whether the email is registered or not
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request				body	  request.ForgotPasswordRequest	true "Forgot Password request"
+// @Success      200  					{object}  response.BaseResponse
+// @Failure      400  					{object}  response.BaseResponse
+// @Router       /auth/forgot-password	[post]
+func (app *Application) forgotPassword(w http.ResponseWriter, r *http.Request) {
+	var data request.ForgotPasswordRequest
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = app.Service.IAuth.ForgotPassword(r.Context(), data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "If the email is registered, a password reset email was sent",
+	})
+}
+
+// @Summary      Reset Password
+// @Description  Set a new password with the token of the password reset email. The token works once, and every login of the account is revoked
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request				body	  request.ResetPasswordRequest	true "Reset Password request"
+// @Success      200  					{object}  response.BaseResponse
+// @Failure      400  					{object}  response.BaseResponse
+// @Router       /auth/reset-password	[post]
+func (app *Application) resetPassword(w http.ResponseWriter, r *http.Request) {
+	var data request.ResetPasswordRequest
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = app.Service.IAuth.ResetPassword(r.Context(), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid or expired reset token")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "Success reset password, please login again",
+	})
+}
+
+// @Summary      Verify Email
+// @Description  Verify the email of an account with the token of the verification email
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request				body	  request.VerifyEmailRequest	true "Verify Email request"
+// @Success      200  					{object}  response.BaseResponse
+// @Failure      400  					{object}  response.BaseResponse
+// @Router       /auth/verify-email		[post]
+func (app *Application) verifyEmail(w http.ResponseWriter, r *http.Request) {
+	var data request.VerifyEmailRequest
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = app.Service.IAuth.VerifyEmail(r.Context(), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid or expired verification token")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "Success verify email",
+	})
+}
+
+// @Summary      Resend Verification Email
+// @Description  Email a new verification link. The response is the same whether the email is registered, already verified or not
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request						body	  request.ResendVerificationRequest	true "Resend Verification request"
+// @Success      200  							{object}  response.BaseResponse
+// @Failure      400  							{object}  response.BaseResponse
+// @Router       /auth/resend-verification		[post]
+func (app *Application) resendVerification(w http.ResponseWriter, r *http.Request) {
+	var data request.ResendVerificationRequest
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, _, err = app.Service.IAuth.RequestEmailVerification(r.Context(), data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "If the email is registered and not verified yet, a verification email was sent",
+	})
+}
+
 func (app *Application) AuthController() *http.ServeMux {
 	authRouter := http.NewServeMux()
 
 	authRouter.HandleFunc("POST /login", app.login)
 	authRouter.HandleFunc("POST /register", app.register)
+	authRouter.HandleFunc("POST /refresh", app.refresh)
+	authRouter.HandleFunc("POST /logout", app.logout)
+	authRouter.HandleFunc("POST /forgot-password", app.forgotPassword)
+	authRouter.HandleFunc("POST /reset-password", app.resetPassword)
+	authRouter.HandleFunc("POST /verify-email", app.verifyEmail)
+	authRouter.HandleFunc("POST /resend-verification", app.resendVerification)
+	authRouter.HandleFunc("POST /2fa/verify", app.verifyMfa)
+	authRouter.Handle("POST /2fa/enroll", app.authentication(http.HandlerFunc(app.enrollTotp)))
+	authRouter.Handle("POST /2fa/confirm", app.authentication(http.HandlerFunc(app.confirmTotp)))
+	authRouter.Handle("POST /2fa/disable", app.authentication(http.HandlerFunc(app.disableTotp)))
+	authRouter.HandleFunc("GET /oidc/{provider}/start", app.startOidc)
+	authRouter.HandleFunc("GET /oidc/{provider}/callback", app.oidcCallback)
 
 	return authRouter
 }
@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+//go:embed templates/changelog/*.html
+var changelogFS embed.FS
+
+var changelogFuncs = template.FuncMap{
+	// markdown.ToHTML sanitizes, it is safe to embed as is
+	"contentHTML": func(post entity.Post) template.HTML {
+		return template.HTML(markdown.ToHTML(post.Content))
+	},
+}
+
+var (
+	changelogIndexTemplate = template.Must(template.New("").Funcs(changelogFuncs).ParseFS(changelogFS, "templates/changelog/layout.html", "templates/changelog/index.html"))
+	changelogPostTemplate  = template.Must(template.New("").Funcs(changelogFuncs).ParseFS(changelogFS, "templates/changelog/layout.html", "templates/changelog/post.html"))
+)
+
+const (
+	changelogPageSize    = 10
+	defaultAccentColor   = "#4f46e5"
+	changelogContentType = "text/html; charset=utf-8"
+)
+
+// changelogPage is the data of the changelog templates.
+type changelogPage struct {
+	Title        string
+	LogoUrl      string
+	AccentColor  string
+	ChangelogURL string
+	FeedBase     string
+
+	// index page
+	Categories []string
+	Category   string
+	Posts      []entity.Post
+	PrevURL    string
+	NextURL    string
+
+	// post page
+	Post entity.Post
+}
+
+func newChangelogPage(project entity.Project) changelogPage {
+	page := changelogPage{
+		Title:        project.PageTitle,
+		LogoUrl:      project.LogoUrl,
+		AccentColor:  project.AccentColor,
+		ChangelogURL: "/changelog/" + url.PathEscape(project.Slug),
+		FeedBase:     "/v1/public/projects/" + url.PathEscape(project.Slug),
+	}
+
+	if page.Title == "" {
+		page.Title = project.Name
+	}
+
+	if page.AccentColor == "" {
+		page.AccentColor = defaultAccentColor
+	}
+
+	return page
+}
+
+// changelogPageURL links to a page of the changelog, keeping the category filter.
+func changelogPageURL(base string, category string, page int) string {
+	query := url.Values{}
+
+	if category != "" {
+		query.Set("category", category)
+	}
+
+	if page > 1 {
+		query.Set("page", strconv.Itoa(page))
+	}
+
+	if len(query) == 0 {
+		return base
+	}
+
+	return base + "?" + query.Encode()
+}
+
+// changelogProject loads the project of the {slug} path value, it returns false when
+// the response is already written.
+func (app *Application) changelogProject(w http.ResponseWriter, r *http.Request) (entity.Project, bool) {
+	project, err := app.Service.IPublic.GetPublicProject(r.Context(), r.PathValue("slug"))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		http.Error(w, "404 page not found", http.StatusNotFound)
+		return entity.Project{}, false
+	}
+
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return entity.Project{}, false
+	}
+
+	return project, true
+}
+
+func (app *Application) writeChangelog(w http.ResponseWriter, r *http.Request, tmpl *template.Template, page changelogPage, project entity.Project, posts []entity.Post) {
+	var body bytes.Buffer
+
+	if err := tmpl.ExecuteTemplate(&body, "layout", page); err != nil {
+		reqID, _ := r.Context().Value(middleware.CtxRequestID).(string)
+		app.Logger.Error("⚠️ Failed to render changelog", zap.String("RequestId", reqID), zap.String("Slug", project.Slug), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// the page only links relatively, a shared cache may keep it whatever the host
+	utils.WriteCached(w, r, changelogContentType, body.Bytes())
+}
+
+// getChangelog renders the hosted changelog page of a project, newest posts first.
+func (app *Application) getChangelog(w http.ResponseWriter, r *http.Request) {
+	var data request.GetPublicPostRequest
+
+	err := publicDecoder.Decode(&data, r.URL.Query())
+
+	if err != nil || app.Validator.Struct(data) != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	data.PageSize = changelogPageSize
+
+	project, ok := app.changelogProject(w, r)
+
+	if !ok {
+		return
+	}
+
+	result, err := app.Service.IPublic.GetPublicPosts(r.Context(), project.ID, data)
+
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	categories, err := app.Service.IPublic.GetPublicCategories(r.Context(), project.ID)
+
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	page := newChangelogPage(project)
+	page.Categories = categories
+	page.Category = data.Category
+	page.Posts = result.Data
+
+	current := result.Pagination.Page
+
+	if current > 1 {
+		page.PrevURL = changelogPageURL(page.ChangelogURL, data.Category, current-1)
+	}
+
+	if current < result.Pagination.TotalPage {
+		page.NextURL = changelogPageURL(page.ChangelogURL, data.Category, current+1)
+	}
+
+	app.writeChangelog(w, r, changelogIndexTemplate, page, project, result.Data)
+}
+
+// getChangelogPost renders a single published post of the hosted changelog.
+func (app *Application) getChangelogPost(w http.ResponseWriter, r *http.Request) {
+	postId, err := strconv.Atoi(r.PathValue("postId"))
+
+	if err != nil {
+		http.Error(w, "404 page not found", http.StatusNotFound)
+		return
+	}
+
+	project, ok := app.changelogProject(w, r)
+
+	if !ok {
+		return
+	}
+
+	post, err := app.Service.IPublic.GetPublicPost(r.Context(), project.ID, uint(postId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		http.Error(w, "404 page not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	page := newChangelogPage(project)
+	page.Post = post
+
+	app.writeChangelog(w, r, changelogPostTemplate, page, project, []entity.Post{post})
+}
+
+func (app *Application) ChangelogController() *http.ServeMux {
+	productRouter := http.NewServeMux()
+
+	productRouter.HandleFunc("GET /{slug}", app.getChangelog)
+	productRouter.HandleFunc("GET /{slug}/{postId}", app.getChangelogPost)
+
+	// Catch-all route for undefined paths
+	productRouter.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "404 page not found", http.StatusNotFound)
+	})
+
+	return productRouter
+}
+
+// changelogURL is the absolute url of the hosted changelog of project.
+func (app *Application) changelogURL(r *http.Request, project entity.Project) string {
+	return fmt.Sprintf("%s/changelog/%s", app.publicBaseURL(r), url.PathEscape(project.Slug))
+}
@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+)
+
+// jwks serves the public keys verifying the JWTs of LogStream, by kid. It is outside
+// /v1 like the changelog pages: verifiers look for it at the well-known path.
+func (app *Application) jwks(w http.ResponseWriter, r *http.Request) {
+	// shorter than the publish ahead of new keys
+	w.Header().Set("Cache-Control", "public, max-age=300")
+
+	utils.WriteJSON(w, http.StatusOK, app.Keys.JWKS())
+}
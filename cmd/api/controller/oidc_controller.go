@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/service"
+	"github.com/ariefzainuri96/go-logstream/internal/sso"
+)
+
+const (
+	// carries the signed state of an OIDC login from start to callback
+	oidcFlowCookie = "logstream_oidc_flow"
+	oidcCookiePath = "/v1/auth/oidc/"
+)
+
+// oidcCookie is the flow cookie, value "" deletes it.
+func (app *Application) oidcCookie(r *http.Request, value string) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     oidcFlowCookie,
+		Value:    value,
+		Path:     oidcCookiePath,
+		MaxAge:   int(sso.FlowTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil || strings.HasPrefix(app.Config.PublicBaseURL, "https://"),
+		// the callback is a top level navigation from the provider
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	if value == "" {
+		cookie.MaxAge = -1
+	}
+
+	return cookie
+}
+
+// @Summary      Start OIDC Login
+// @Description  Redirect to the login page of an OIDC provider configured with OIDC_PROVIDERS, open it in the browser. The provider redirects back to /auth/oidc/{provider}/callback
+// @Tags         auth
+// @Param        provider						path      string  true  "Provider name, e.g. okta"
+// @Success      302
+// @Failure      404  							{object}  response.BaseResponse
+// @Failure      502  							{object}  response.BaseResponse
+// @Router       /auth/oidc/{provider}/start	[get]
+func (app *Application) startOidc(w http.ResponseWriter, r *http.Request) {
+	authURL, flow, err := app.Service.IOidc.StartOidc(r.Context(), r.PathValue("provider"))
+
+	if errors.Is(err, service.ErrOidcUnknownProvider) {
+		utils.RespondError(w, http.StatusNotFound, "Provider not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadGateway, "The provider is unavailable, please retry later")
+		return
+	}
+
+	http.SetCookie(w, app.oidcCookie(r, flow))
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// @Summary      OIDC Login Callback
+// @Description  The provider redirects here after the login. The account with the verified email of the provider is logged in, or created. Users with 2FA get mfa_required and an mfa_token like on /auth/login
+// @Tags         auth
+// @Produce      json
+// @Param        provider						path      string  true  "Provider name, e.g. okta"
+// @Param        code							query     string  true  "Authorization code"
+// @Param        state							query     string  true  "State of the login"
+// @Success      200  							{object}  response.LoginResponse
+// @Failure      400  							{object}  response.BaseResponse
+// @Failure      401  							{object}  response.BaseResponse
+// @Failure      403  							{object}  response.BaseResponse
+// @Failure      404  							{object}  response.BaseResponse
+// @Router       /auth/oidc/{provider}/callback	[get]
+func (app *Application) oidcCallback(w http.ResponseWriter, r *http.Request) {
+	// a flow is used once, whatever the outcome
+	http.SetCookie(w, app.oidcCookie(r, ""))
+
+	query := r.URL.Query()
+
+	// e.g. access_denied when the user cancels at the provider
+	if query.Get("error") != "" {
+		utils.RespondError(w, http.StatusUnauthorized, "Login at the provider was cancelled or failed")
+		return
+	}
+
+	data := request.OidcCallbackRequest{
+		Code:  query.Get("code"),
+		State: query.Get("state"),
+	}
+
+	err := app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var flow string
+
+	if cookie, err := r.Cookie(oidcFlowCookie); err == nil {
+		flow = cookie.Value
+	}
+
+	user, tokens, err := app.Service.IOidc.FinishOidc(r.Context(), r.PathValue("provider"), flow, data)
+
+	if errors.Is(err, service.ErrOidcUnknownProvider) {
+		utils.RespondError(w, http.StatusNotFound, "Provider not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrOidcInvalidFlow) {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid or expired login, please start again")
+		return
+	}
+
+	if errors.Is(err, service.ErrIdentityEmailNotVerified) {
+		utils.RespondError(w, http.StatusForbidden, "The provider didn't verify your email")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusUnauthorized, "Login at the provider failed, please start again")
+		return
+	}
+
+	respondLogin(w, user, tokens)
+}
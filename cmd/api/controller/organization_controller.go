@@ -0,0 +1,451 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/service"
+	"gorm.io/gorm"
+)
+
+// orgRole wraps a handler of a /{id} route with the minimum role the caller needs in
+// the org, see middleware.OrgRoleHandler.
+func (app *Application) orgRole(minimum string, next http.HandlerFunc) http.HandlerFunc {
+	return middleware.OrgRoleHandler(app.Service.IOrganization.GetOrgMemberRole, minimum, next)
+}
+
+// @Summary      Add Organization
+// @Description  Create an organization to share projects with a team, the caller becomes its owner
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        request		body	  request.AddOrganizationRequest	true "Add Organization request"
+// @security 	 ApiKeyAuth
+// @Success      200  			{object}  response.OrganizationResponse
+// @Failure      400  			{object}  response.BaseResponse
+// @Router       /orgs/			[post]
+func (app *Application) addOrganization(w http.ResponseWriter, r *http.Request) {
+	var data request.AddOrganizationRequest
+
+	user, ok := middleware.GetUserFromContext(r)
+
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized, please re login!")
+		return
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	organization, err := app.Service.IOrganization.AddOrganization(r.Context(), user["user_id"].(uint), data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.OrganizationResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success add organization",
+		},
+		Organization: organization,
+	})
+}
+
+// @Summary      Get Organizations
+// @Description  Get the organizations of the caller, the personal organization first
+// @Tags         organization
+// @Produce      json
+// @security 	 ApiKeyAuth
+// @Success      200  			{object}  response.OrganizationsResponse
+// @Router       /orgs/			[get]
+func (app *Application) getOrganizations(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r)
+
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized, please re login!")
+		return
+	}
+
+	organizations, err := app.Service.IOrganization.GetOrganizations(r.Context(), user["user_id"].(uint))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.OrganizationsResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Organizations: organizations,
+	})
+}
+
+// @Summary      Get Organization
+// @Description  Get an organization, any member can read it
+// @Tags         organization
+// @Produce      json
+// @Param        id   			path      int  true  "Organization ID"
+// @security 	 ApiKeyAuth
+// @Success      200  			{object}  response.OrganizationResponse
+// @Failure      400  			{object}  response.BaseResponse
+// @Failure      404  			{object}  response.BaseResponse
+// @Router       /orgs/{id}		[get]
+func (app *Application) getOrganization(w http.ResponseWriter, r *http.Request) {
+	orgId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	organization, err := app.Service.IOrganization.GetOrganization(r.Context(), uint(orgId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.OrganizationResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Organization: organization,
+	})
+}
+
+// @Summary      Update Organization
+// @Description  Update the name and billing email of an organization, owners only
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        id   			path      int  true  "Organization ID"
+// @Param        request		body	  request.AddOrganizationRequest	true "Update Organization request"
+// @security 	 ApiKeyAuth
+// @Success      200  			{object}  response.OrganizationResponse
+// @Failure      400  			{object}  response.BaseResponse
+// @Failure      403  			{object}  response.BaseResponse
+// @Failure      404  			{object}  response.BaseResponse
+// @Router       /orgs/{id}		[put]
+func (app *Application) updateOrganization(w http.ResponseWriter, r *http.Request) {
+	var data request.AddOrganizationRequest
+
+	orgId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	organization, err := app.Service.IOrganization.UpdateOrganization(r.Context(), uint(orgId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.OrganizationResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success update organization",
+		},
+		Organization: organization,
+	})
+}
+
+// @Summary      Get Organization Members
+// @Description  Get the members of an organization, any member can list them
+// @Tags         organization
+// @Produce      json
+// @Param        id   					path      int  true  "Organization ID"
+// @security 	 ApiKeyAuth
+// @Success      200  					{object}  response.OrgMembersResponse
+// @Failure      400  					{object}  response.BaseResponse
+// @Failure      404  					{object}  response.BaseResponse
+// @Router       /orgs/{id}/members		[get]
+func (app *Application) getOrgMembers(w http.ResponseWriter, r *http.Request) {
+	orgId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	members, err := app.Service.IOrganization.GetOrgMembers(r.Context(), uint(orgId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.OrgMembersResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Members: members,
+	})
+}
+
+// @Summary      Add Organization Member
+// @Description  Add a user with a verified email to an organization with a role (owner, editor or viewer) in all its projects, owners only. Personal organizations can't get members
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        id   					path      int  true  "Organization ID"
+// @Param        request				body	  request.AddOrgMemberRequest	true "Add Organization Member request"
+// @security 	 ApiKeyAuth
+// @Success      200  					{object}  response.OrgMemberResponse
+// @Failure      400  					{object}  response.BaseResponse
+// @Failure      403  					{object}  response.BaseResponse
+// @Failure      404  					{object}  response.BaseResponse
+// @Failure      409  					{object}  response.BaseResponse
+// @Router       /orgs/{id}/members		[post]
+func (app *Application) addOrgMember(w http.ResponseWriter, r *http.Request) {
+	var data request.AddOrgMemberRequest
+
+	orgId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	member, err := app.Service.IOrganization.AddOrgMember(r.Context(), uint(orgId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Organization or user not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrMemberExists) || errors.Is(err, service.ErrPersonalOrg) {
+		utils.RespondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.OrgMemberResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success add member",
+		},
+		Member: member,
+	})
+}
+
+// @Summary      Update Organization Member
+// @Description  Change the role of an organization member, owners only. The last owner can't be demoted
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        id   							path      int  true  "Organization ID"
+// @Param        memberId						path      int  true  "Member ID"
+// @Param        request						body	  request.UpdateOrgMemberRequest	true "Update Organization Member request"
+// @security 	 ApiKeyAuth
+// @Success      200  							{object}  response.OrgMemberResponse
+// @Failure      400  							{object}  response.BaseResponse
+// @Failure      403  							{object}  response.BaseResponse
+// @Failure      404  							{object}  response.BaseResponse
+// @Failure      409  							{object}  response.BaseResponse
+// @Router       /orgs/{id}/members/{memberId}	[put]
+func (app *Application) updateOrgMember(w http.ResponseWriter, r *http.Request) {
+	var data request.UpdateOrgMemberRequest
+
+	orgId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	memberId, err := strconv.Atoi(r.PathValue("memberId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid member id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	member, err := app.Service.IOrganization.UpdateOrgMember(r.Context(), uint(orgId), uint(memberId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrLastOwner) {
+		utils.RespondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.OrgMemberResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success update member",
+		},
+		Member: member,
+	})
+}
+
+// @Summary      Delete Organization Member
+// @Description  Remove a member from an organization, owners only. The last owner can't be removed
+// @Tags         organization
+// @Produce      json
+// @Param        id   							path      int  true  "Organization ID"
+// @Param        memberId						path      int  true  "Member ID"
+// @security 	 ApiKeyAuth
+// @Success      200  							{object}  response.BaseResponse
+// @Failure      400  							{object}  response.BaseResponse
+// @Failure      403  							{object}  response.BaseResponse
+// @Failure      404  							{object}  response.BaseResponse
+// @Failure      409  							{object}  response.BaseResponse
+// @Router       /orgs/{id}/members/{memberId}	[delete]
+func (app *Application) deleteOrgMember(w http.ResponseWriter, r *http.Request) {
+	orgId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	memberId, err := strconv.Atoi(r.PathValue("memberId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid member id")
+		return
+	}
+
+	err = app.Service.IOrganization.DeleteOrgMember(r.Context(), uint(orgId), uint(memberId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrLastOwner) {
+		utils.RespondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "Success delete member",
+	})
+}
+
+func (app *Application) OrganizationController() *http.ServeMux {
+	productRouter := http.NewServeMux()
+
+	productRouter.HandleFunc("POST /", app.addOrganization)
+	productRouter.HandleFunc("GET /", app.getOrganizations)
+	productRouter.HandleFunc("GET /{id}", app.orgRole(entity.ProjectRoleViewer, app.getOrganization))
+	productRouter.HandleFunc("PUT /{id}", app.orgRole(entity.ProjectRoleOwner, app.updateOrganization))
+
+	productRouter.HandleFunc("GET /{id}/members", app.orgRole(entity.ProjectRoleViewer, app.getOrgMembers))
+	productRouter.HandleFunc("POST /{id}/members", app.orgRole(entity.ProjectRoleOwner, app.addOrgMember))
+	productRouter.HandleFunc("PUT /{id}/members/{memberId}", app.orgRole(entity.ProjectRoleOwner, app.updateOrgMember))
+	productRouter.HandleFunc("DELETE /{id}/members/{memberId}", app.orgRole(entity.ProjectRoleOwner, app.deleteOrgMember))
+
+	// Catch-all route for undefined paths
+	productRouter.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "404 page not found", http.StatusNotFound)
+	})
+
+	return productRouter
+}
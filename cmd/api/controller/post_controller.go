@@ -1,16 +1,23 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/service"
+	internalutils "github.com/ariefzainuri96/go-logstream/internal/utils"
+	"gorm.io/gorm"
 )
 
 // @Summary      Add Post
-// @Description  Add new Post
+// @Description  Add new Post, a draft with publish_at is published by the scheduler at that time
 // @Tags         post
 // @Accept       json
 // @Produce      json
@@ -18,6 +25,7 @@ import (
 // @security 	 ApiKeyAuth
 // @Success      200  			{object}  response.PostResponse
 // @Failure      400  			{object}  response.BaseResponse
+// @Failure      403  			{object}  response.BaseResponse
 // @Failure      404  			{object}  response.BaseResponse
 // @Router       /posts/		[post]
 func (app *Application) addPost(w http.ResponseWriter, r *http.Request) {
@@ -38,8 +46,23 @@ func (app *Application) addPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if data.PublishAt != nil && data.Status == "published" {
+		utils.RespondError(w, http.StatusBadRequest, "publish_at can only be set on draft posts")
+		return
+	}
+
 	post, err := app.Service.IPost.CreatePost(r.Context(), data)
 
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrInsufficientRole) {
+		utils.RespondError(w, http.StatusForbidden, "You are not authorized to perform this action!")
+		return
+	}
+
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
 		return
@@ -50,7 +73,7 @@ func (app *Application) addPost(w http.ResponseWriter, r *http.Request) {
 			Status:  http.StatusOK,
 			Message: "Success add post",
 		},
-		Post: post,
+		Post: response.NewPost(post),
 	})
 }
 
@@ -63,6 +86,7 @@ func (app *Application) addPost(w http.ResponseWriter, r *http.Request) {
 // @security 	 ApiKeyAuth
 // @Success      200  			{object}  response.PostsResponse
 // @Failure      400  			{object}  response.BaseResponse
+// @Failure      403  			{object}  response.BaseResponse
 // @Failure      404  			{object}  response.BaseResponse
 // @Router       /posts/		[get]
 func (app *Application) getPost(w http.ResponseWriter, r *http.Request) {
@@ -77,6 +101,16 @@ func (app *Application) getPost(w http.ResponseWriter, r *http.Request) {
 
 	result, err := app.Service.IPost.GetPost(r.Context(), data)
 
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrInsufficientRole) {
+		utils.RespondError(w, http.StatusForbidden, "You are not authorized to perform this action!")
+		return
+	}
+
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
 		return
@@ -87,16 +121,308 @@ func (app *Application) getPost(w http.ResponseWriter, r *http.Request) {
 			Message: "Success",
 			Status:  http.StatusOK,
 		},
-		Posts:      result.Data,
+		Posts:      internalutils.MapSlice(result.Data, response.NewPost),
 		Pagination: result.Pagination,
 	})
 }
 
+// @Summary      Get Post By Id
+// @Description  Get a single Post
+// @Tags         post
+// @Produce      json
+// @Param        id   				path      int  true  "Post ID"
+// @security 	 ApiKeyAuth
+// @Success      200  				{object}  response.PostResponse
+// @Failure      400  				{object}  response.BaseResponse
+// @Failure      403  				{object}  response.BaseResponse
+// @Failure      404  				{object}  response.BaseResponse
+// @Router       /posts/{id}		[get]
+func (app *Application) getPostById(w http.ResponseWriter, r *http.Request) {
+	postId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	post, err := app.Service.IPost.GetPostById(r.Context(), uint(postId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrInsufficientRole) {
+		utils.RespondError(w, http.StatusForbidden, "You are not authorized to perform this action!")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.PostResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Post: response.NewPost(post),
+	})
+}
+
+// @Summary      Update Post
+// @Description  Replace title, content and category of a Post, fires post.updated
+// @Tags         post
+// @Accept       json
+// @Produce      json
+// @Param        id   				path      int  true  "Post ID"
+// @Param        request			body	  request.UpdatePostRequest	true "Update Post request"
+// @security 	 ApiKeyAuth
+// @Success      200  				{object}  response.PostResponse
+// @Failure      400  				{object}  response.BaseResponse
+// @Failure      403  				{object}  response.BaseResponse
+// @Failure      404  				{object}  response.BaseResponse
+// @Router       /posts/{id}		[put]
+func (app *Application) updatePost(w http.ResponseWriter, r *http.Request) {
+	var data request.UpdatePostRequest
+
+	postId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	post, err := app.Service.IPost.UpdatePost(r.Context(), uint(postId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrInsufficientRole) {
+		utils.RespondError(w, http.StatusForbidden, "You are not authorized to perform this action!")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.PostResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success update post",
+		},
+		Post: response.NewPost(post),
+	})
+}
+
+// @Summary      Patch Post
+// @Description  Update only the sent fields of a Post, fires post.updated
+// @Tags         post
+// @Accept       json
+// @Produce      json
+// @Param        id   				path      int  true  "Post ID"
+// @Param        request			body	  request.PatchPostRequest	true "Patch Post request"
+// @security 	 ApiKeyAuth
+// @Success      200  				{object}  response.PostResponse
+// @Failure      400  				{object}  response.BaseResponse
+// @Failure      403  				{object}  response.BaseResponse
+// @Failure      404  				{object}  response.BaseResponse
+// @Router       /posts/{id}		[patch]
+func (app *Application) patchPost(w http.ResponseWriter, r *http.Request) {
+	var data request.PatchPostRequest
+
+	postId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	post, err := app.Service.IPost.PatchPost(r.Context(), uint(postId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrInsufficientRole) {
+		utils.RespondError(w, http.StatusForbidden, "You are not authorized to perform this action!")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.PostResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success update post",
+		},
+		Post: response.NewPost(post),
+	})
+}
+
+// @Summary      Delete Post
+// @Description  Delete a Post, fires post.deleted
+// @Tags         post
+// @Produce      json
+// @Param        id   				path      int  true  "Post ID"
+// @security 	 ApiKeyAuth
+// @Success      200  				{object}  response.BaseResponse
+// @Failure      400  				{object}  response.BaseResponse
+// @Failure      403  				{object}  response.BaseResponse
+// @Failure      404  				{object}  response.BaseResponse
+// @Router       /posts/{id}		[delete]
+func (app *Application) deletePost(w http.ResponseWriter, r *http.Request) {
+	postId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = app.Service.IPost.DeletePost(r.Context(), uint(postId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrInsufficientRole) {
+		utils.RespondError(w, http.StatusForbidden, "You are not authorized to perform this action!")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "Success delete post",
+	})
+}
+
+// @Summary      Publish Post
+// @Description  Move a draft Post to published, fires post.published
+// @Tags         post
+// @Produce      json
+// @Param        id   					path      int  true  "Post ID"
+// @security 	 ApiKeyAuth
+// @Success      200  					{object}  response.PostResponse
+// @Failure      400  					{object}  response.BaseResponse
+// @Failure      403  					{object}  response.BaseResponse
+// @Failure      404  					{object}  response.BaseResponse
+// @Failure      409  					{object}  response.BaseResponse
+// @Router       /posts/{id}/publish	[post]
+func (app *Application) publishPost(w http.ResponseWriter, r *http.Request) {
+	app.transitionPost(w, r, app.Service.IPost.PublishPost, "Success publish post")
+}
+
+// @Summary      Unpublish Post
+// @Description  Move a published Post back to draft, fires post.unpublished
+// @Tags         post
+// @Produce      json
+// @Param        id   					path      int  true  "Post ID"
+// @security 	 ApiKeyAuth
+// @Success      200  					{object}  response.PostResponse
+// @Failure      400  					{object}  response.BaseResponse
+// @Failure      403  					{object}  response.BaseResponse
+// @Failure      404  					{object}  response.BaseResponse
+// @Failure      409  					{object}  response.BaseResponse
+// @Router       /posts/{id}/unpublish	[post]
+func (app *Application) unpublishPost(w http.ResponseWriter, r *http.Request) {
+	app.transitionPost(w, r, app.Service.IPost.UnpublishPost, "Success unpublish post")
+}
+
+func (app *Application) transitionPost(w http.ResponseWriter, r *http.Request, transition func(context.Context, uint) (entity.Post, error), message string) {
+	postId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	post, err := transition(r.Context(), uint(postId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrInsufficientRole) {
+		utils.RespondError(w, http.StatusForbidden, "You are not authorized to perform this action!")
+		return
+	}
+
+	if errors.Is(err, service.ErrInvalidPostTransition) {
+		utils.RespondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.PostResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: message,
+		},
+		Post: response.NewPost(post),
+	})
+}
+
 func (app *Application) PostController() *http.ServeMux {
 	productRouter := http.NewServeMux()
 
 	productRouter.HandleFunc("POST /", app.addPost)
 	productRouter.HandleFunc("GET /", app.getPost)
+	productRouter.HandleFunc("GET /{id}", app.getPostById)
+	productRouter.HandleFunc("PUT /{id}", app.updatePost)
+	productRouter.HandleFunc("PATCH /{id}", app.patchPost)
+	productRouter.HandleFunc("DELETE /{id}", app.deletePost)
+	productRouter.HandleFunc("POST /{id}/publish", app.publishPost)
+	productRouter.HandleFunc("POST /{id}/unpublish", app.unpublishPost)
 
 	// Catch-all route for undefined paths
 	productRouter.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
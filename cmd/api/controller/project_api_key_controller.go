@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"gorm.io/gorm"
+)
+
+// @Summary      Get Project API Keys
+// @Description  Get the API keys of a project, revoked ones included, owners only. The keys themselves aren't returned, only their prefix
+// @Tags         apikey
+// @Produce      json
+// @Param        id   					path      int  true  "Project ID"
+// @security 	 ApiKeyAuth
+// @Success      200  					{object}  response.ProjectApiKeysResponse
+// @Failure      400  					{object}  response.BaseResponse
+// @Failure      403  					{object}  response.BaseResponse
+// @Failure      404  					{object}  response.BaseResponse
+// @Router       /projects/{id}/keys		[get]
+func (app *Application) getProjectApiKeys(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	keys, err := app.Service.IProjectApiKey.GetApiKeys(r.Context(), uint(projectId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.ProjectApiKeysResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Keys: keys,
+	})
+}
+
+// @Summary      Add Project API Key
+// @Description  Create an API key for CI and scripts, scoped to posts:read and/or posts:write of the project, owners only. The key is only returned in this response, send it as "Authorization: ApiKey <key>"
+// @Tags         apikey
+// @Accept       json
+// @Produce      json
+// @Param        id   					path      int  true  "Project ID"
+// @Param        request				body	  request.AddProjectApiKeyRequest	true "Add Project API Key request"
+// @security 	 ApiKeyAuth
+// @Success      200  					{object}  response.ProjectApiKeyResponse
+// @Failure      400  					{object}  response.BaseResponse
+// @Failure      403  					{object}  response.BaseResponse
+// @Failure      404  					{object}  response.BaseResponse
+// @Router       /projects/{id}/keys		[post]
+func (app *Application) addProjectApiKey(w http.ResponseWriter, r *http.Request) {
+	var data request.AddProjectApiKeyRequest
+
+	user, ok := middleware.GetUserFromContext(r)
+
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized, please re login!")
+		return
+	}
+
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key, err := app.Service.IProjectApiKey.AddApiKey(r.Context(), uint(projectId), user["user_id"].(uint), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.ProjectApiKeyResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success add api key, copy the key now, it won't be shown again",
+		},
+		Key: key,
+	})
+}
+
+// @Summary      Revoke Project API Key
+// @Description  Revoke an API key of a project, requests with it are rejected right away, owners only
+// @Tags         apikey
+// @Produce      json
+// @Param        id   						path      int  true  "Project ID"
+// @Param        keyId						path      int  true  "API Key ID"
+// @security 	 ApiKeyAuth
+// @Success      200  						{object}  response.BaseResponse
+// @Failure      400  						{object}  response.BaseResponse
+// @Failure      403  						{object}  response.BaseResponse
+// @Failure      404  						{object}  response.BaseResponse
+// @Router       /projects/{id}/keys/{keyId}	[delete]
+func (app *Application) revokeProjectApiKey(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	keyId, err := strconv.Atoi(r.PathValue("keyId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid key id")
+		return
+	}
+
+	err = app.Service.IProjectApiKey.RevokeApiKey(r.Context(), uint(projectId), uint(keyId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "API key not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "Success revoke api key",
+	})
+}
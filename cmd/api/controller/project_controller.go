@@ -2,14 +2,19 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/service"
+	"github.com/ariefzainuri96/go-logstream/internal/webhook"
 	"github.com/gorilla/schema"
+	"gorm.io/gorm"
 )
 
 var decoder = schema.NewDecoder()
@@ -23,6 +28,7 @@ var decoder = schema.NewDecoder()
 // @security 	 ApiKeyAuth
 // @Success      200  			{object}  response.ProjectResponse
 // @Failure      400  			{object}  response.BaseResponse
+// @Failure      403  			{object}  response.BaseResponse
 // @Failure      404  			{object}  response.BaseResponse
 // @Router       /projects/		[post]
 func (app *Application) addProject(w http.ResponseWriter, r *http.Request) {
@@ -52,6 +58,21 @@ func (app *Application) addProject(w http.ResponseWriter, r *http.Request) {
 
 	project, err := app.Service.IProject.AddProject(r.Context(), user["user_id"].(uint), data)
 
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrInsufficientRole) {
+		utils.RespondError(w, http.StatusForbidden, "You are not authorized to perform this action!")
+		return
+	}
+
+	if errors.Is(err, webhook.ErrInvalidUrl) {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
 		return
@@ -119,6 +140,7 @@ func (app *Application) getProject(w http.ResponseWriter, r *http.Request) {
 // @security 	 ApiKeyAuth
 // @Success      200  				{object}  response.BaseResponse
 // @Failure      400  				{object}  response.BaseResponse
+// @Failure      403  				{object}  response.BaseResponse
 // @Failure      404  				{object}  response.BaseResponse
 // @Router       /projects/{id}		[delete]
 func (app *Application) deleteProject(w http.ResponseWriter, r *http.Request) {
@@ -131,6 +153,11 @@ func (app *Application) deleteProject(w http.ResponseWriter, r *http.Request) {
 
 	err = app.Service.IProject.DeleteProject(r.Context(), uint(id))
 
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -152,6 +179,7 @@ func (app *Application) deleteProject(w http.ResponseWriter, r *http.Request) {
 // @security 	 ApiKeyAuth
 // @Success      200  				{object}  response.ProjectResponse
 // @Failure      400  				{object}  response.BaseResponse
+// @Failure      403  				{object}  response.BaseResponse
 // @Failure      404  				{object}  response.BaseResponse
 // @Router       /projects/{id}		[put]
 func (app *Application) updateProject(w http.ResponseWriter, r *http.Request) {
@@ -172,8 +200,20 @@ func (app *Application) updateProject(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	project, err := app.Service.IProject.UpdateProject(r.Context(), uint(productID), data)
 
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -193,8 +233,32 @@ func (app *Application) ProjectController() *http.ServeMux {
 
 	productRouter.HandleFunc("POST /", app.addProject)
 	productRouter.HandleFunc("GET /", app.getProject)
-	productRouter.HandleFunc("DELETE /{id}", app.deleteProject)
-	productRouter.HandleFunc("PUT /{id}", app.updateProject)
+	productRouter.HandleFunc("DELETE /{id}", app.projectRole(entity.ProjectRoleOwner, app.deleteProject))
+	productRouter.HandleFunc("PUT /{id}", app.projectRole(entity.ProjectRoleOwner, app.updateProject))
+
+	// webhooks, their secret and delivery log are managed by owners
+	productRouter.HandleFunc("GET /{id}/webhooks", app.projectRole(entity.ProjectRoleOwner, app.getProjectWebhooks))
+	productRouter.HandleFunc("POST /{id}/webhooks", app.projectRole(entity.ProjectRoleOwner, app.addProjectWebhook))
+	productRouter.HandleFunc("POST /{id}/webhooks/preview", app.projectRole(entity.ProjectRoleOwner, app.previewWebhookTemplate))
+	productRouter.HandleFunc("POST /{id}/webhooks/test", app.projectRole(entity.ProjectRoleOwner, app.testWebhook))
+	productRouter.HandleFunc("GET /{id}/webhooks/{webhookId}", app.projectRole(entity.ProjectRoleOwner, app.getProjectWebhook))
+	productRouter.HandleFunc("PUT /{id}/webhooks/{webhookId}", app.projectRole(entity.ProjectRoleOwner, app.updateProjectWebhook))
+	productRouter.HandleFunc("DELETE /{id}/webhooks/{webhookId}", app.projectRole(entity.ProjectRoleOwner, app.deleteProjectWebhook))
+	productRouter.HandleFunc("GET /{id}/webhooks/secret", app.projectRole(entity.ProjectRoleOwner, app.getWebhookSecret))
+	productRouter.HandleFunc("POST /{id}/webhooks/secret/rotate", app.projectRole(entity.ProjectRoleOwner, app.rotateWebhookSecret))
+	productRouter.HandleFunc("GET /{id}/webhooks/deliveries", app.projectRole(entity.ProjectRoleOwner, app.getWebhookDeliveries))
+	productRouter.HandleFunc("GET /{id}/webhooks/deliveries/{deliveryId}", app.projectRole(entity.ProjectRoleOwner, app.getWebhookDelivery))
+	productRouter.HandleFunc("POST /{id}/webhooks/deliveries/{deliveryId}/redeliver", app.projectRole(entity.ProjectRoleOwner, app.redeliverWebhook))
+
+	productRouter.HandleFunc("GET /{id}/members", app.projectRole(entity.ProjectRoleViewer, app.getProjectMembers))
+	productRouter.HandleFunc("POST /{id}/members", app.projectRole(entity.ProjectRoleOwner, app.addProjectMember))
+	productRouter.HandleFunc("PUT /{id}/members/{memberId}", app.projectRole(entity.ProjectRoleOwner, app.updateProjectMember))
+	productRouter.HandleFunc("DELETE /{id}/members/{memberId}", app.projectRole(entity.ProjectRoleOwner, app.deleteProjectMember))
+
+	// API keys for CI, they can only be managed with a user token
+	productRouter.HandleFunc("GET /{id}/keys", app.projectRole(entity.ProjectRoleOwner, app.getProjectApiKeys))
+	productRouter.HandleFunc("POST /{id}/keys", app.projectRole(entity.ProjectRoleOwner, app.addProjectApiKey))
+	productRouter.HandleFunc("DELETE /{id}/keys/{keyId}", app.projectRole(entity.ProjectRoleOwner, app.revokeProjectApiKey))
 
 	// Catch-all route for undefined paths
 	productRouter.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
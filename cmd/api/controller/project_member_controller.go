@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/service"
+	"gorm.io/gorm"
+)
+
+// projectRole wraps a handler of a /{id} route with the minimum role the caller needs
+// in the project, see middleware.ProjectRoleHandler.
+func (app *Application) projectRole(minimum string, next http.HandlerFunc) http.HandlerFunc {
+	return middleware.ProjectRoleHandler(app.Service.IProjectMember.GetMemberRole, minimum, next)
+}
+
+// @Summary      Get Project Members
+// @Description  Get the members of a project and the pending invitations, any member can list them
+// @Tags         member
+// @Produce      json
+// @Param        id   						path      int  true  "Project ID"
+// @security 	 ApiKeyAuth
+// @Success      200  						{object}  response.ProjectMembersResponse
+// @Failure      400  						{object}  response.BaseResponse
+// @Failure      404  						{object}  response.BaseResponse
+// @Router       /projects/{id}/members		[get]
+func (app *Application) getProjectMembers(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	members, err := app.Service.IProjectMember.GetMembers(r.Context(), uint(projectId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.ProjectMembersResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Members: members,
+	})
+}
+
+// @Summary      Invite Project Member
+// @Description  Invite an email to a project with a role (owner, editor or viewer), owners only. The invitation is accepted when the email registers, right away when it already has an account
+// @Tags         member
+// @Accept       json
+// @Produce      json
+// @Param        id   						path      int  true  "Project ID"
+// @Param        request					body	  request.AddProjectMemberRequest	true "Add Project Member request"
+// @security 	 ApiKeyAuth
+// @Success      200  						{object}  response.ProjectMemberResponse
+// @Failure      400  						{object}  response.BaseResponse
+// @Failure      403  						{object}  response.BaseResponse
+// @Failure      404  						{object}  response.BaseResponse
+// @Failure      409  						{object}  response.BaseResponse
+// @Router       /projects/{id}/members		[post]
+func (app *Application) addProjectMember(w http.ResponseWriter, r *http.Request) {
+	var data request.AddProjectMemberRequest
+
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	member, err := app.Service.IProjectMember.AddMember(r.Context(), uint(projectId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrMemberExists) {
+		utils.RespondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.ProjectMemberResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success invite member",
+		},
+		Member: member,
+	})
+}
+
+// @Summary      Update Project Member
+// @Description  Change the role of a member, owners only. The last owner can't be demoted
+// @Tags         member
+// @Accept       json
+// @Produce      json
+// @Param        id   								path      int  true  "Project ID"
+// @Param        memberId							path      int  true  "Member ID"
+// @Param        request							body	  request.UpdateProjectMemberRequest	true "Update Project Member request"
+// @security 	 ApiKeyAuth
+// @Success      200  								{object}  response.ProjectMemberResponse
+// @Failure      400  								{object}  response.BaseResponse
+// @Failure      403  								{object}  response.BaseResponse
+// @Failure      404  								{object}  response.BaseResponse
+// @Failure      409  								{object}  response.BaseResponse
+// @Router       /projects/{id}/members/{memberId}	[put]
+func (app *Application) updateProjectMember(w http.ResponseWriter, r *http.Request) {
+	var data request.UpdateProjectMemberRequest
+
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	memberId, err := strconv.Atoi(r.PathValue("memberId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid member id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	member, err := app.Service.IProjectMember.UpdateMember(r.Context(), uint(projectId), uint(memberId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrLastOwner) {
+		utils.RespondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.ProjectMemberResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success update member",
+		},
+		Member: member,
+	})
+}
+
+// @Summary      Delete Project Member
+// @Description  Remove a member or cancel an invitation, owners only. The last owner can't be removed
+// @Tags         member
+// @Produce      json
+// @Param        id   								path      int  true  "Project ID"
+// @Param        memberId							path      int  true  "Member ID"
+// @security 	 ApiKeyAuth
+// @Success      200  								{object}  response.BaseResponse
+// @Failure      400  								{object}  response.BaseResponse
+// @Failure      403  								{object}  response.BaseResponse
+// @Failure      404  								{object}  response.BaseResponse
+// @Failure      409  								{object}  response.BaseResponse
+// @Router       /projects/{id}/members/{memberId}	[delete]
+func (app *Application) deleteProjectMember(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	memberId, err := strconv.Atoi(r.PathValue("memberId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid member id")
+		return
+	}
+
+	err = app.Service.IProjectMember.DeleteMember(r.Context(), uint(projectId), uint(memberId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrLastOwner) {
+		utils.RespondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "Success delete member",
+	})
+}
@@ -0,0 +1,412 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/webhook"
+	"gorm.io/gorm"
+)
+
+// @Summary      Get Project Webhooks
+// @Description  Get all webhooks of a project
+// @Tags         webhook
+// @Produce      json
+// @Param        id   						path      int  true  "Project ID"
+// @security 	 ApiKeyAuth
+// @Success      200  						{object}  response.ProjectWebhooksResponse
+// @Failure      400  						{object}  response.BaseResponse
+// @Failure      403  						{object}  response.BaseResponse
+// @Failure      404  						{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks	[get]
+func (app *Application) getProjectWebhooks(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	webhooks, err := app.Service.IProjectWebhook.GetWebhooks(r.Context(), uint(projectId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.ProjectWebhooksResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Webhooks: webhooks,
+	})
+}
+
+// @Summary      Get Project Webhook
+// @Description  Get a single webhook of a project
+// @Tags         webhook
+// @Produce      json
+// @Param        id   									path      int  true  "Project ID"
+// @Param        webhookId								path      int  true  "Webhook ID"
+// @security 	 ApiKeyAuth
+// @Success      200  									{object}  response.ProjectWebhookResponse
+// @Failure      400  									{object}  response.BaseResponse
+// @Failure      403  									{object}  response.BaseResponse
+// @Failure      404  									{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/{webhookId}	[get]
+func (app *Application) getProjectWebhook(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	webhookId, err := strconv.Atoi(r.PathValue("webhookId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid webhook id")
+		return
+	}
+
+	projectWebhook, err := app.Service.IProjectWebhook.GetWebhook(r.Context(), uint(projectId), uint(webhookId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.ProjectWebhookResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Webhook: projectWebhook,
+	})
+}
+
+// @Summary      Add Project Webhook
+// @Description  Add a webhook subscribed to post events
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   						path      int  true  "Project ID"
+// @Param        request					body	  request.AddProjectWebhookRequest	true "Add Project Webhook request"
+// @security 	 ApiKeyAuth
+// @Success      200  						{object}  response.ProjectWebhookResponse
+// @Failure      400  						{object}  response.BaseResponse
+// @Failure      403  						{object}  response.BaseResponse
+// @Failure      404  						{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks	[post]
+func (app *Application) addProjectWebhook(w http.ResponseWriter, r *http.Request) {
+	var data request.AddProjectWebhookRequest
+
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	projectWebhook, err := app.Service.IProjectWebhook.AddWebhook(r.Context(), uint(projectId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if errors.Is(err, webhook.ErrInvalidUrl) {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.ProjectWebhookResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success add webhook",
+		},
+		Webhook: projectWebhook,
+	})
+}
+
+// @Summary      Update Project Webhook
+// @Description  Update url, provider, enabled flag and subscribed events of a webhook
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   									path      int  true  "Project ID"
+// @Param        webhookId								path      int  true  "Webhook ID"
+// @Param        request								body	  request.AddProjectWebhookRequest	true "Update Project Webhook request"
+// @security 	 ApiKeyAuth
+// @Success      200  									{object}  response.ProjectWebhookResponse
+// @Failure      400  									{object}  response.BaseResponse
+// @Failure      403  									{object}  response.BaseResponse
+// @Failure      404  									{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/{webhookId}	[put]
+func (app *Application) updateProjectWebhook(w http.ResponseWriter, r *http.Request) {
+	var data request.AddProjectWebhookRequest
+
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	webhookId, err := strconv.Atoi(r.PathValue("webhookId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid webhook id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	projectWebhook, err := app.Service.IProjectWebhook.UpdateWebhook(r.Context(), uint(projectId), uint(webhookId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	if errors.Is(err, webhook.ErrInvalidUrl) {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.ProjectWebhookResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success update webhook",
+		},
+		Webhook: projectWebhook,
+	})
+}
+
+// @Summary      Delete Project Webhook
+// @Description  Delete a webhook, its delivery log is kept
+// @Tags         webhook
+// @Produce      json
+// @Param        id   									path      int  true  "Project ID"
+// @Param        webhookId								path      int  true  "Webhook ID"
+// @security 	 ApiKeyAuth
+// @Success      200  									{object}  response.BaseResponse
+// @Failure      400  									{object}  response.BaseResponse
+// @Failure      403  									{object}  response.BaseResponse
+// @Failure      404  									{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/{webhookId}	[delete]
+func (app *Application) deleteProjectWebhook(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	webhookId, err := strconv.Atoi(r.PathValue("webhookId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid webhook id")
+		return
+	}
+
+	err = app.Service.IProjectWebhook.DeleteWebhook(r.Context(), uint(projectId), uint(webhookId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "Success delete webhook",
+	})
+}
+
+// @Summary      Preview Webhook Template
+// @Description  Render a payload template against a sample post, template errors are returned as 400
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   								path      int  true  "Project ID"
+// @Param        request							body	  request.PreviewWebhookTemplateRequest	true "Preview Webhook Template request"
+// @security 	 ApiKeyAuth
+// @Success      200  								{object}  response.WebhookPreviewResponse
+// @Failure      400  								{object}  response.BaseResponse
+// @Failure      403  								{object}  response.BaseResponse
+// @Failure      404  								{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/preview	[post]
+func (app *Application) previewWebhookTemplate(w http.ResponseWriter, r *http.Request) {
+	var data request.PreviewWebhookTemplateRequest
+
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	payload, err := app.Service.IWebhookTester.PreviewTemplate(r.Context(), uint(projectId), data)
+
+	if errors.Is(err, webhook.ErrInvalidTemplate) || errors.Is(err, webhook.ErrInvalidUrl) {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.WebhookPreviewResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Payload: payload,
+	})
+}
+
+// @Summary      Test Webhook
+// @Description  Send a sample post to a saved webhook (webhook_id) or to an unsaved url and return the status code and latency of the receiver. Urls must resolve to a public address
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   							path      int  true  "Project ID"
+// @Param        request						body	  request.TestWebhookRequest	true "Test Webhook request"
+// @security 	 ApiKeyAuth
+// @Success      200  							{object}  response.WebhookTestResponse
+// @Failure      400  							{object}  response.BaseResponse
+// @Failure      403  							{object}  response.BaseResponse
+// @Failure      404  							{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/test	[post]
+func (app *Application) testWebhook(w http.ResponseWriter, r *http.Request) {
+	var data request.TestWebhookRequest
+
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := app.Service.IWebhookTester.TestWebhook(r.Context(), uint(projectId), data)
+
+	if errors.Is(err, webhook.ErrInvalidTemplate) || errors.Is(err, webhook.ErrInvalidUrl) || errors.Is(err, webhook.ErrForbiddenAddress) {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project or webhook not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// the test itself ran, a failing receiver is reported in result
+	utils.WriteJSON(w, http.StatusOK, response.WebhookTestResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Result: result,
+	})
+}
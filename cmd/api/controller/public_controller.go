@@ -1,49 +1,349 @@
 package controller
 
-import "net/http"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
 
-// @Summary      Get All Project
-// @Description  Get All Project
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/feed"
+	internalutils "github.com/ariefzainuri96/go-logstream/internal/utils"
+	"github.com/gorilla/schema"
+	"gorm.io/gorm"
+)
+
+// publicDecoder decodes the queries of the public endpoints, tagged with `url` (e.g.
+// page_size). They may get extra params like cache busters, those are ignored.
+var publicDecoder = newPublicDecoder()
+
+func newPublicDecoder() *schema.Decoder {
+	d := schema.NewDecoder()
+	d.SetAliasTag("url")
+	d.IgnoreUnknownKeys(true)
+
+	return d
+}
+
+// lastModified returns the latest change of a project and its posts, the updated
+// date of the feeds.
+func lastModified(project entity.Project, posts []entity.Post) time.Time {
+	latest := project.CreatedAt
+
+	if project.UpdatedAt != nil && project.UpdatedAt.After(latest) {
+		latest = *project.UpdatedAt
+	}
+
+	for _, post := range posts {
+		if post.PublishedDate().After(latest) {
+			latest = post.PublishedDate()
+		}
+
+		if post.UpdatedAt != nil && post.UpdatedAt.After(latest) {
+			latest = *post.UpdatedAt
+		}
+	}
+
+	return latest
+}
+
+// publicProject loads the project of the {slug} path value and applies its CORS
+// allow-list. It returns false when the response is already written (not found,
+// error or preflight).
+func (app *Application) publicProject(w http.ResponseWriter, r *http.Request) (entity.Project, bool) {
+	project, err := app.Service.IPublic.GetPublicProject(r.Context(), r.PathValue("slug"))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return entity.Project{}, false
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return entity.Project{}, false
+	}
+
+	if middleware.PublicCORS(w, r, project.AllowedOrigins) {
+		return entity.Project{}, false
+	}
+
+	return project, true
+}
+
+// publicPreflight answers CORS preflight requests of every public project route.
+func (app *Application) publicPreflight(w http.ResponseWriter, r *http.Request) {
+	app.publicProject(w, r)
+}
+
+// @Summary      Get Public Project
+// @Description  Get a project by slug, no authentication required. Supports If-None-Match
 // @Tags         public
-// @Accept       json
 // @Produce      json
-// @Param        request					query	  request.PaginationRequest	true "Get Project request"
-// @security 	 ApiKeyAuth
-// @Success      200  						{object}  response.ProjectsResponse
-// @Failure      400  						{object}  response.BaseResponse
-// @Failure      404  						{object}  response.BaseResponse
-// @Router       /public/projects/{projectId}		[get]
+// @Param        slug   						path      string  true  "Project slug"
+// @Success      200  							{object}  response.PublicProjectResponse
+// @Success      304
+// @Failure      404  							{object}  response.BaseResponse
+// @Router       /public/projects/{slug}		[get]
 func (app *Application) getPublicProject(w http.ResponseWriter, r *http.Request) {
-	// var data request.PaginationRequest
+	project, ok := app.publicProject(w, r)
+
+	if !ok {
+		return
+	}
+
+	utils.WriteCachedJSON(w, r, response.PublicProjectResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Project: response.NewPublicProject(project),
+	})
+}
+
+// @Summary      Get Public Posts
+// @Description  Get published posts of a project by slug, newest first, no authentication required. Supports If-None-Match
+// @Tags         public
+// @Produce      json
+// @Param        slug   							path      string  true  "Project slug"
+// @Param        request							query	  request.GetPublicPostRequest	false "Get Public Post request"
+// @Success      200  								{object}  response.PublicPostsResponse
+// @Success      304
+// @Failure      400  								{object}  response.BaseResponse
+// @Failure      404  								{object}  response.BaseResponse
+// @Router       /public/projects/{slug}/posts		[get]
+func (app *Application) getPublicPosts(w http.ResponseWriter, r *http.Request) {
+	var data request.GetPublicPostRequest
+
+	err := publicDecoder.Decode(&data, r.URL.Query())
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	project, ok := app.publicProject(w, r)
+
+	if !ok {
+		return
+	}
+
+	result, err := app.Service.IPublic.GetPublicPosts(r.Context(), project.ID, data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteCachedJSON(w, r, response.PublicPostsResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Project:    response.NewPublicProject(project),
+		Posts:      internalutils.MapSlice(result.Data, response.NewPublicPost),
+		Pagination: result.Pagination,
+	})
+}
+
+// feedSize is the number of latest posts in a feed
+const feedSize = 50
+
+// publicBaseURL is Config.PublicBaseURL, or the scheme and host the request came in on.
+// X-Forwarded-Proto is only read when TrustProxyHeaders is set.
+func (app *Application) publicBaseURL(r *http.Request) string {
+	if app.Config.PublicBaseURL != "" {
+		return app.Config.PublicBaseURL
+	}
 
-	// err := decoder.Decode(&data, r.URL.Query())
+	scheme := "http"
 
-	// if err != nil {
-	// 	utils.RespondError(w, http.StatusBadRequest, "Invalid request")
-	// 	return
-	// }
+	if r.TLS != nil || (app.Config.TrustProxyHeaders && r.Header.Get("X-Forwarded-Proto") == "https") {
+		scheme = "https"
+	}
 
-	// product, err := app.store.IProduct.GetProduct(r.Context(), data)
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
+
+// privateUnlessBaseURL keeps shared caches from storing a response with links built
+// from the request host when PUBLIC_BASE_URL isn't set, a forged Host would otherwise
+// be served to everyone.
+func (app *Application) privateUnlessBaseURL(w http.ResponseWriter) {
+	if app.Config.PublicBaseURL == "" {
+		w.Header().Set("Cache-Control", "private, max-age=60")
+	}
+}
+
+// @Summary      Get Public RSS Feed
+// @Description  RSS 2.0 feed of the latest published posts of a project, no authentication required. Supports If-None-Match
+// @Tags         public
+// @Produce      application/rss+xml
+// @Param        slug   								path      string  true  "Project slug"
+// @Param        category								query     string  false "Only posts of this category"
+// @Success      200  									{string}  string
+// @Success      304
+// @Failure      404  									{object}  response.BaseResponse
+// @Router       /public/projects/{slug}/feed.rss		[get]
+func (app *Application) getPublicFeedRSS(w http.ResponseWriter, r *http.Request) {
+	app.writeFeed(w, r, "feed.rss", feed.RSSContentType, feed.RSS)
+}
+
+// @Summary      Get Public Atom Feed
+// @Description  Atom 1.0 feed of the latest published posts of a project, no authentication required. Supports If-None-Match
+// @Tags         public
+// @Produce      application/atom+xml
+// @Param        slug   								path      string  true  "Project slug"
+// @Param        category								query     string  false "Only posts of this category"
+// @Success      200  									{string}  string
+// @Success      304
+// @Failure      404  									{object}  response.BaseResponse
+// @Router       /public/projects/{slug}/feed.atom		[get]
+func (app *Application) getPublicFeedAtom(w http.ResponseWriter, r *http.Request) {
+	app.writeFeed(w, r, "feed.atom", feed.AtomContentType, feed.Atom)
+}
+
+// @Summary      Get Public JSON Feed
+// @Description  JSON Feed 1.1 of the latest published posts of a project, no authentication required. Supports If-None-Match
+// @Tags         public
+// @Produce      application/feed+json
+// @Param        slug   								path      string  true  "Project slug"
+// @Param        category								query     string  false "Only posts of this category"
+// @Success      200  									{string}  string
+// @Success      304
+// @Failure      404  									{object}  response.BaseResponse
+// @Router       /public/projects/{slug}/feed.json		[get]
+func (app *Application) getPublicFeedJSON(w http.ResponseWriter, r *http.Request) {
+	app.writeFeed(w, r, "feed.json", feed.JSONFeedContentType, feed.JSONFeed)
+}
+
+func (app *Application) writeFeed(w http.ResponseWriter, r *http.Request, name string, contentType string, render func(feed.Feed) ([]byte, error)) {
+	project, ok := app.publicProject(w, r)
+
+	if !ok {
+		return
+	}
+
+	result, err := app.Service.IPublic.GetPublicPosts(r.Context(), project.ID, request.GetPublicPostRequest{
+		Page:     1,
+		PageSize: feedSize,
+		Category: r.URL.Query().Get("category"),
+	})
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	self := fmt.Sprintf("%s/v1/public/projects/%s/%s", app.publicBaseURL(r), url.PathEscape(project.Slug), name)
 
-	// if err != nil {
-	// 	utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
-	// 	return
-	// }
+	if r.URL.RawQuery != "" {
+		self += "?" + r.URL.RawQuery
+	}
+
+	body, err := render(feed.Feed{
+		Project: project,
+		Posts:   result.Data,
+		Link:    app.changelogURL(r, project),
+		Self:    self,
+		Updated: lastModified(project, result.Data),
+	})
 
-	// utils.WriteJSON(w, http.StatusOK, response.ProductsResponse{
-	// 	BaseResponse: response.BaseResponse{
-	// 		Message: "Success",
-	// 		Status:  http.StatusOK,
-	// 	},
-	// 	Products:   product.Data,
-	// 	Pagination: product.Pagination,
-	// })
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	app.privateUnlessBaseURL(w)
+	utils.WriteCached(w, r, contentType, body)
+}
+
+// @Summary      Get Public Widget
+// @Description  Latest published posts of a project and the number of posts published after since, for "what's new" widgets. No authentication required
+// @Tags         public
+// @Produce      json
+// @Param        slug   							path      string  true  "Project slug"
+// @Param        request							query	  request.GetWidgetRequest	false "Get Widget request"
+// @Success      200  								{object}  response.WidgetResponse
+// @Success      304
+// @Failure      400  								{object}  response.BaseResponse
+// @Failure      404  								{object}  response.BaseResponse
+// @Router       /public/projects/{slug}/widget		[get]
+func (app *Application) getPublicWidget(w http.ResponseWriter, r *http.Request) {
+	var data request.GetWidgetRequest
+
+	err := publicDecoder.Decode(&data, r.URL.Query())
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var since time.Time
+
+	if data.Since != "" {
+		since, _ = time.Parse(time.RFC3339, data.Since) // checked by the validator
+	}
+
+	project, ok := app.publicProject(w, r)
+
+	if !ok {
+		return
+	}
+
+	result, err := app.Service.IPublic.GetPublicPosts(r.Context(), project.ID, data.Posts())
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	unseen, err := app.Service.IPublic.CountPublicPostsSince(r.Context(), project.ID, since)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteCachedJSON(w, r, response.WidgetResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Project:     response.NewPublicProject(project),
+		Posts:       internalutils.MapSlice(result.Data, response.NewPublicPost),
+		UnseenCount: unseen,
+	})
 }
 
 func (app *Application) PublicController() *http.ServeMux {
 	productRouter := http.NewServeMux()
 
-	productRouter.HandleFunc("GET /projects/{projectId}", app.getPublicProject)
+	productRouter.HandleFunc("GET /projects/{slug}", app.getPublicProject)
+	productRouter.HandleFunc("GET /projects/{slug}/posts", app.getPublicPosts)
+	productRouter.HandleFunc("GET /projects/{slug}/feed.rss", app.getPublicFeedRSS)
+	productRouter.HandleFunc("GET /projects/{slug}/feed.atom", app.getPublicFeedAtom)
+	productRouter.HandleFunc("GET /projects/{slug}/feed.json", app.getPublicFeedJSON)
+	productRouter.HandleFunc("GET /projects/{slug}/widget", app.getPublicWidget)
+	productRouter.HandleFunc("OPTIONS /projects/{slug}", app.publicPreflight)
+	productRouter.HandleFunc("OPTIONS /projects/{slug}/{path...}", app.publicPreflight)
 
 	// Catch-all route for undefined paths
 	productRouter.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/service"
+	"gorm.io/gorm"
+)
+
+// @Summary      Verify 2FA
+// @Description  Finish the login of a user with 2FA: the mfa_token of /auth/login and a code of the authenticator app or a recovery code. Wrong codes count as failed logins and get 429 with Retry-After when repeated
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request			body	  request.VerifyMfaRequest	true "Verify 2FA request"
+// @Success      200  				{object}  response.LoginResponse
+// @Failure      400  				{object}  response.BaseResponse
+// @Failure      401  				{object}  response.BaseResponse
+// @Failure      429  				{object}  response.BaseResponse
+// @Router       /auth/2fa/verify	[post]
+func (app *Application) verifyMfa(w http.ResponseWriter, r *http.Request) {
+	var data request.VerifyMfaRequest
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := middleware.WithClientIP(r.Context(), middleware.ClientIP(r, app.Config.TrustProxyHeaders))
+
+	user, tokens, err := app.Service.ILogin.VerifyMfa(ctx, data)
+
+	if respondThrottled(w, err) {
+		return
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusUnauthorized, "Invalid or expired mfa token, please re login!")
+		return
+	}
+
+	if errors.Is(err, service.ErrInvalidTotpCode) {
+		utils.RespondError(w, http.StatusUnauthorized, "Invalid code")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	respondLogin(w, user, tokens)
+}
+
+// @Summary      Enroll 2FA
+// @Description  Create a TOTP secret for the authenticator app, 2FA is enabled once /auth/2fa/confirm gets a code of it. Enrolling again replaces a secret that isn't confirmed yet
+// @Tags         auth
+// @Produce      json
+// @security 	 ApiKeyAuth
+// @Success      200  				{object}  response.TotpEnrollmentResponse
+// @Failure      401  				{object}  response.BaseResponse
+// @Failure      409  				{object}  response.BaseResponse
+// @Router       /auth/2fa/enroll	[post]
+func (app *Application) enrollTotp(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r)
+
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized, please re login!")
+		return
+	}
+
+	enrollment, err := app.Service.IAuth.EnrollTotp(r.Context(), user["user_id"].(uint))
+
+	if errors.Is(err, service.ErrTotpAlreadyEnabled) {
+		utils.RespondError(w, http.StatusConflict, "2FA is already enabled")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.TotpEnrollmentResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success enroll 2FA, confirm it with a code of the authenticator app",
+		},
+		Data: response.TotpEnrollmentData{
+			Secret:     enrollment.Secret,
+			OtpauthUrl: enrollment.URI,
+			QrCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(enrollment.QRCode),
+		},
+	})
+}
+
+// @Summary      Confirm 2FA
+// @Description  Enable 2FA with a code of the secret of /auth/2fa/enroll. The response carries the recovery codes, they are only shown once
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request			body	  request.TotpCodeRequest	true "Confirm 2FA request"
+// @security 	 ApiKeyAuth
+// @Success      200  				{object}  response.RecoveryCodesResponse
+// @Failure      400  				{object}  response.BaseResponse
+// @Failure      401  				{object}  response.BaseResponse
+// @Failure      409  				{object}  response.BaseResponse
+// @Failure      429  				{object}  response.BaseResponse
+// @Router       /auth/2fa/confirm	[post]
+func (app *Application) confirmTotp(w http.ResponseWriter, r *http.Request) {
+	var data request.TotpCodeRequest
+
+	user, ok := middleware.GetUserFromContext(r)
+
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized, please re login!")
+		return
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := middleware.WithClientIP(r.Context(), middleware.ClientIP(r, app.Config.TrustProxyHeaders))
+
+	codes, err := app.Service.IAuth.ConfirmTotp(ctx, user["user_id"].(uint), data)
+
+	if respondThrottled(w, err) {
+		return
+	}
+
+	if errors.Is(err, service.ErrInvalidTotpCode) {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid code")
+		return
+	}
+
+	if errors.Is(err, service.ErrTotpNotEnrolled) {
+		utils.RespondError(w, http.StatusBadRequest, "Please enroll 2FA first")
+		return
+	}
+
+	if errors.Is(err, service.ErrTotpAlreadyEnabled) {
+		utils.RespondError(w, http.StatusConflict, "2FA is already enabled")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.RecoveryCodesResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success enable 2FA, save the recovery codes now, they won't be shown again",
+		},
+		RecoveryCodes: codes,
+	})
+}
+
+// @Summary      Disable 2FA
+// @Description  Disable 2FA with a code of the authenticator app or a recovery code, the secret and the recovery codes are dropped
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request			body	  request.TotpCodeRequest	true "Disable 2FA request"
+// @security 	 ApiKeyAuth
+// @Success      200  				{object}  response.BaseResponse
+// @Failure      400  				{object}  response.BaseResponse
+// @Failure      401  				{object}  response.BaseResponse
+// @Failure      409  				{object}  response.BaseResponse
+// @Failure      429  				{object}  response.BaseResponse
+// @Router       /auth/2fa/disable	[post]
+func (app *Application) disableTotp(w http.ResponseWriter, r *http.Request) {
+	var data request.TotpCodeRequest
+
+	user, ok := middleware.GetUserFromContext(r)
+
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized, please re login!")
+		return
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	defer r.Body.Close()
+
+	err = app.Validator.Struct(data)
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := middleware.WithClientIP(r.Context(), middleware.ClientIP(r, app.Config.TrustProxyHeaders))
+
+	err = app.Service.IAuth.DisableTotp(ctx, user["user_id"].(uint), data)
+
+	if respondThrottled(w, err) {
+		return
+	}
+
+	if errors.Is(err, service.ErrInvalidTotpCode) {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid code")
+		return
+	}
+
+	if errors.Is(err, service.ErrTotpNotEnabled) {
+		utils.RespondError(w, http.StatusConflict, "2FA is not enabled")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "Success disable 2FA",
+	})
+}
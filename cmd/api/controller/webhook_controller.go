@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/service"
+	"gorm.io/gorm"
+)
+
+// @Summary      Get Webhook Deliveries
+// @Description  Get webhook delivery log of a project, including every attempt
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   										path      int  true  "Project ID"
+// @Param        request									query	  request.GetWebhookDeliveryRequest	true "Get Webhook Delivery request"
+// @security 	 ApiKeyAuth
+// @Success      200  										{object}  response.WebhookDeliveriesResponse
+// @Failure      400  										{object}  response.BaseResponse
+// @Failure      403  										{object}  response.BaseResponse
+// @Failure      404  										{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/deliveries		[get]
+func (app *Application) getWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	var data request.GetWebhookDeliveryRequest
+
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	err = decoder.Decode(&data, r.URL.Query())
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	result, err := app.Service.IWebhookDelivery.GetDeliveries(r.Context(), uint(projectId), data)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.WebhookDeliveriesResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Deliveries: result.Data,
+		Pagination: result.Pagination,
+	})
+}
+
+// @Summary      Get Webhook Delivery
+// @Description  Get a single webhook delivery with every attempt
+// @Tags         webhook
+// @Produce      json
+// @Param        id   													path      int  true  "Project ID"
+// @Param        deliveryId											path      int  true  "Delivery ID"
+// @security 	 ApiKeyAuth
+// @Success      200  													{object}  response.WebhookDeliveryResponse
+// @Failure      400  													{object}  response.BaseResponse
+// @Failure      403  													{object}  response.BaseResponse
+// @Failure      404  													{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/deliveries/{deliveryId}		[get]
+func (app *Application) getWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	deliveryId, err := strconv.Atoi(r.PathValue("deliveryId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid delivery id")
+		return
+	}
+
+	delivery, err := app.Service.IWebhookDelivery.GetDelivery(r.Context(), uint(projectId), uint(deliveryId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Delivery not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.WebhookDeliveryResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		Delivery: delivery,
+	})
+}
+
+// @Summary      Redeliver Webhook
+// @Description  Queue a new delivery with the same payload as a previous one, sent to the current url of its webhook
+// @Tags         webhook
+// @Produce      json
+// @Param        id   																path      int  true  "Project ID"
+// @Param        deliveryId														path      int  true  "Delivery ID"
+// @security 	 ApiKeyAuth
+// @Success      200  																{object}  response.WebhookDeliveryResponse
+// @Failure      400  																{object}  response.BaseResponse
+// @Failure      403  																{object}  response.BaseResponse
+// @Failure      404  																{object}  response.BaseResponse
+// @Failure      409  																{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/deliveries/{deliveryId}/redeliver		[post]
+func (app *Application) redeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	deliveryId, err := strconv.Atoi(r.PathValue("deliveryId"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid delivery id")
+		return
+	}
+
+	delivery, err := app.Service.IWebhookDelivery.RedeliverDelivery(r.Context(), uint(projectId), uint(deliveryId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Delivery not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrWebhookGone) {
+		utils.RespondError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	if errors.Is(err, service.ErrWebhookDisabled) {
+		utils.RespondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.WebhookDeliveryResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success redeliver webhook",
+		},
+		Delivery: delivery,
+	})
+}
+
+// @Summary      Get Webhook Secret
+// @Description  Get the secret used to sign webhook requests (X-LogStream-Signature)
+// @Tags         webhook
+// @Produce      json
+// @Param        id   							path      int  true  "Project ID"
+// @security 	 ApiKeyAuth
+// @Success      200  							{object}  response.WebhookSecretResponse
+// @Failure      400  							{object}  response.BaseResponse
+// @Failure      403  							{object}  response.BaseResponse
+// @Failure      404  							{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/secret	[get]
+func (app *Application) getWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	secret, err := app.Service.IProject.GetWebhookSecret(r.Context(), uint(projectId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.WebhookSecretResponse{
+		BaseResponse: response.BaseResponse{
+			Message: "Success",
+			Status:  http.StatusOK,
+		},
+		WebhookSecret: secret,
+	})
+}
+
+// @Summary      Rotate Webhook Secret
+// @Description  Generate a new webhook signing secret, the previous one stops working immediately
+// @Tags         webhook
+// @Produce      json
+// @Param        id   									path      int  true  "Project ID"
+// @security 	 ApiKeyAuth
+// @Success      200  									{object}  response.WebhookSecretResponse
+// @Failure      400  									{object}  response.BaseResponse
+// @Failure      403  									{object}  response.BaseResponse
+// @Failure      404  									{object}  response.BaseResponse
+// @Router       /projects/{id}/webhooks/secret/rotate	[post]
+func (app *Application) rotateWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.Atoi(r.PathValue("id"))
+
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	secret, err := app.Service.IProject.RotateWebhookSecret(r.Context(), uint(projectId))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response.WebhookSecretResponse{
+		BaseResponse: response.BaseResponse{
+			Status:  http.StatusOK,
+			Message: "Success rotate webhook secret",
+		},
+		WebhookSecret: secret,
+	})
+}
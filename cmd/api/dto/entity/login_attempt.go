@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	_ "gorm.io/gorm"
+)
+
+// @Model
+type LoginAttempt struct {
+	// e.g. email:jane@example.com, ip:203.0.113.7
+	Key           string     `gorm:"type:varchar(320);primaryKey;column:key" json:"key"`
+	Failures      int        `gorm:"not null;column:failures" json:"failures"`
+	LastFailureAt time.Time  `gorm:"not null;column:last_failure_at" json:"last_failure_at"`
+	LockedUntil   *time.Time `gorm:"column:locked_until" json:"locked_until"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
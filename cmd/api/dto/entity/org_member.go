@@ -0,0 +1,26 @@
+package entity
+
+import (
+	_ "gorm.io/gorm"
+)
+
+// @Model
+type OrgMember struct {
+	UpdateEntity
+	OrgId  uint   `gorm:"type:int;not null;column:org_id" json:"org_id"`
+	UserId uint   `gorm:"type:int;not null;column:user_id" json:"user_id"`
+	Role   string `gorm:"type:varchar(20);not null;column:role" json:"role"` // 'owner', 'editor', 'viewer', the role in every project of the org
+	// users.email, only read when the members are listed
+	Email string `gorm:"->;column:email" json:"email"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (OrgMember) TableName() string {
+	return "org_members"
+}
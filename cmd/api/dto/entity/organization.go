@@ -0,0 +1,28 @@
+package entity
+
+import (
+	_ "gorm.io/gorm"
+)
+
+// @Model
+type Organization struct {
+	UpdateEntity
+	Name string `gorm:"type:varchar(255);not null;column:name" json:"name"`
+	// billing identity of the org's products, the personal org of a user has none
+	BillingEmail string `gorm:"type:varchar(255);column:billing_email" json:"billing_email"`
+	// set on the personal org of a user, it can't be left or handed over
+	PersonalUserId *uint `gorm:"type:int;column:personal_user_id" json:"personal_user_id"`
+
+	Members []OrgMember `gorm:"foreignKey:OrgId" json:"members,omitempty"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (Organization) TableName() string {
+	return "organizations"
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	_ "gorm.io/gorm"
+)
+
+// @Model
+type PasswordResetToken struct {
+	BaseEntity
+	UserId    uint       `gorm:"type:int;not null;column:user_id" json:"user_id"`
+	TokenHash string     `gorm:"type:varchar(64);not null;column:token_hash" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null;column:expires_at" json:"expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"used_at"`
+	// the emailed token, only set when it is created
+	Token string `gorm:"-" json:"-"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
@@ -1,18 +1,22 @@
 package entity
 
 import (
-	_ "gorm.io/gorm"
+	"time"
 )
 
 // @Model
 type Post struct {
-	BaseEntity
+	UpdateEntity
 	ProjectId uint    `gorm:"type:int;not null;column:project_id" json:"project_id"`
 	Project   Project `json:"project"`
 	Title     string  `gorm:"type:varchar(255);not null;column:title" json:"title"`
 	Content   string  `gorm:"type:text;not null;column:content" json:"content"`
 	Category  string  `gorm:"type:varchar(50);not null;column:category" json:"category"` // 'feature', 'bugfix', 'maintenance'
 	Status    string  `gorm:"type:varchar(20);not null;column:status" json:"status"` // 'draft', 'published'
+	// drafts are published by the post scheduler once this time is reached
+	PublishAt *time.Time `gorm:"column:publish_at" json:"publish_at"`
+	// set each time the post is published, empty for drafts
+	PublishedAt *time.Time `gorm:"column:published_at" json:"published_at"`
 }
 
 /*
@@ -25,3 +29,13 @@ type Post struct {
 func (Post) TableName() string {
 	return "posts"
 }
+
+// PublishedDate is the date of the post in listings and feeds, when it was published
+// rather than when the draft was written.
+func (p Post) PublishedDate() time.Time {
+	if p.PublishedAt != nil {
+		return *p.PublishedAt
+	}
+
+	return p.CreatedAt
+}
@@ -1,18 +1,27 @@
 package entity
 
 import (
+	"github.com/lib/pq"
 	_ "gorm.io/gorm"
 )
 
 // @Model
 type Project struct {
 	UpdateEntity
-	UserId uint `gorm:"type:int;not null;column:user_id" json:"user_id"`
-	// User       User   `json:"user"`
-	Name            string `gorm:"type:varchar(255);not null;column:name" json:"name"`
-	Slug            string `gorm:"type:varchar(255);not null;column:slug" json:"slug"`
-	WebhookProvider string `gorm:"type:varchar(255);column:webhook_provider" json:"webhook_provider"`
-	WebhookUrl      string `gorm:"type:text;column:webhook_url" json:"webhook_url"`
+	// the organization owning the project, its members share the project
+	OrgId uint `gorm:"type:int;not null;column:org_id" json:"org_id"`
+	Name          string `gorm:"type:varchar(255);not null;column:name" json:"name"`
+	Slug          string `gorm:"type:varchar(255);not null;column:slug" json:"slug"`
+	WebhookSecret string `gorm:"type:varchar(255);column:webhook_secret" json:"-"` // only returned by the webhook secret endpoints
+	// origins allowed to call the public API from a browser (CORS), '*' allows any
+	AllowedOrigins pq.StringArray `gorm:"type:text[];not null;column:allowed_origins" json:"allowed_origins" swaggertype:"array,string"`
+	// hosted changelog page (/changelog/{slug}) settings
+	PageTitle   string `gorm:"type:varchar(255);column:page_title" json:"page_title"`
+	LogoUrl     string `gorm:"type:text;column:logo_url" json:"logo_url"`
+	AccentColor string `gorm:"type:varchar(7);column:accent_color" json:"accent_color"` // e.g., '#4f46e5'
+
+	Webhooks []ProjectWebhook `json:"webhooks,omitempty"`
+	Members  []ProjectMember  `json:"members,omitempty"`
 }
 
 /*
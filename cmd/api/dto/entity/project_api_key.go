@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+	_ "gorm.io/gorm"
+)
+
+const (
+	ApiKeyScopePostsRead  = "posts:read"  // reads posts of the project, drafts included
+	ApiKeyScopePostsWrite = "posts:write" // creates, edits and publishes posts of the project
+)
+
+var ApiKeyScopes = []string{
+	ApiKeyScopePostsRead,
+	ApiKeyScopePostsWrite,
+}
+
+// @Model
+type ProjectApiKey struct {
+	BaseEntity
+	ProjectId  uint           `gorm:"type:int;not null;column:project_id" json:"project_id"`
+	Name       string         `gorm:"type:varchar(100);not null;column:name" json:"name"`
+	Prefix     string         `gorm:"type:varchar(20);not null;column:prefix" json:"prefix"`
+	KeyHash    string         `gorm:"type:varchar(64);not null;column:key_hash" json:"-"`
+	Scopes     pq.StringArray `gorm:"type:text[];not null;column:scopes" json:"scopes" swaggertype:"array,string"` // 'posts:read', 'posts:write'
+	CreatedBy  *uint          `gorm:"type:int;column:created_by" json:"created_by"`
+	LastUsedAt *time.Time     `gorm:"column:last_used_at" json:"last_used_at"`
+	RevokedAt  *time.Time     `gorm:"column:revoked_at" json:"revoked_at"`
+	// the whole key, only returned when it is created
+	Key string `gorm:"-" json:"key,omitempty"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (ProjectApiKey) TableName() string {
+	return "project_api_keys"
+}
+
+// AllowsRole reports whether the key may do what the minimum project role can. Keys
+// only reach posts: posts:read stands for viewer and posts:write for editor.
+func (k ProjectApiKey) AllowsRole(minimum string) bool {
+	switch minimum {
+	case ProjectRoleViewer:
+		return slices.Contains(k.Scopes, ApiKeyScopePostsRead)
+	case ProjectRoleEditor:
+		return slices.Contains(k.Scopes, ApiKeyScopePostsWrite)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"slices"
+
+	_ "gorm.io/gorm"
+)
+
+const (
+	ProjectRoleOwner  = "owner"  // manages the project, its webhooks and members
+	ProjectRoleEditor = "editor" // creates, edits and publishes posts
+	ProjectRoleViewer = "viewer" // reads posts, drafts included
+)
+
+// ProjectRoles from the least to the most privileged, each role can do what the
+// roles before it can
+var ProjectRoles = []string{
+	ProjectRoleViewer,
+	ProjectRoleEditor,
+	ProjectRoleOwner,
+}
+
+// @Model
+type ProjectMember struct {
+	UpdateEntity
+	ProjectId uint `gorm:"type:int;not null;column:project_id" json:"project_id"`
+	// nil while the invitation is pending, it is set when the email registers
+	UserId *uint  `gorm:"type:int;column:user_id" json:"user_id"`
+	Email  string `gorm:"type:varchar(255);not null;column:email" json:"email"`
+	Role   string `gorm:"type:varchar(20);not null;column:role" json:"role"` // 'owner', 'editor', 'viewer'
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (ProjectMember) TableName() string {
+	return "project_members"
+}
+
+// HasProjectRole reports whether role grants at least the permissions of minimum.
+func HasProjectRole(role string, minimum string) bool {
+	index := slices.Index(ProjectRoles, role)
+
+	return index >= 0 && index >= slices.Index(ProjectRoles, minimum)
+}
+
+// HighestProjectRole returns the most privileged of roles.
+func HighestProjectRole(roles []string) string {
+	highest := ""
+
+	for _, role := range roles {
+		if slices.Index(ProjectRoles, role) > slices.Index(ProjectRoles, highest) {
+			highest = role
+		}
+	}
+
+	return highest
+}
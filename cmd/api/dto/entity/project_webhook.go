@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"slices"
+
+	"github.com/lib/pq"
+	_ "gorm.io/gorm"
+)
+
+const (
+	WebhookEventPostCreated     = "post.created"
+	WebhookEventPostPublished   = "post.published"
+	WebhookEventPostUnpublished = "post.unpublished"
+	WebhookEventPostUpdated     = "post.updated"
+	WebhookEventPostDeleted     = "post.deleted"
+)
+
+var WebhookEvents = []string{
+	WebhookEventPostCreated,
+	WebhookEventPostPublished,
+	WebhookEventPostUnpublished,
+	WebhookEventPostUpdated,
+	WebhookEventPostDeleted,
+}
+
+// @Model
+type ProjectWebhook struct {
+	UpdateEntity
+	ProjectId uint           `gorm:"type:int;not null;column:project_id" json:"project_id"`
+	Url       string         `gorm:"type:text;not null;column:url" json:"url"`
+	Provider  string         `gorm:"type:varchar(255);not null;column:provider" json:"provider"`
+	Enabled   bool           `gorm:"not null;column:enabled" json:"enabled"`
+	Events    pq.StringArray `gorm:"type:text[];not null;column:events" json:"events" swaggertype:"array,string"` // 'post.created', 'post.published', 'post.updated', 'post.deleted'
+	// optional text/template rendered instead of the provider payload
+	PayloadTemplate string `gorm:"type:text;column:payload_template" json:"payload_template"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (ProjectWebhook) TableName() string {
+	return "project_webhooks"
+}
+
+func (w ProjectWebhook) Subscribed(event string) bool {
+	return w.Enabled && slices.Contains(w.Events, event)
+}
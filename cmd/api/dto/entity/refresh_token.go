@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	_ "gorm.io/gorm"
+)
+
+// @Model
+type RefreshToken struct {
+	BaseEntity
+	UserId    uint       `gorm:"type:int;not null;column:user_id" json:"user_id"`
+	FamilyId  string     `gorm:"type:varchar(64);not null;column:family_id" json:"family_id"`
+	TokenHash string     `gorm:"type:varchar(64);not null;column:token_hash" json:"-"`
+	AccessJti string     `gorm:"type:varchar(64);not null;column:access_jti" json:"access_jti"`
+	ExpiresAt time.Time  `gorm:"not null;column:expires_at" json:"expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"used_at"`
+	RevokedAt *time.Time `gorm:"column:revoked_at" json:"revoked_at"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// AuthTokens are issued on login and refresh, they aren't stored as is.
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	// lifetime of AccessToken in seconds
+	ExpiresIn int64
+	// set instead of the tokens when the user has 2FA, the tokens are issued once a
+	// code is sent with it
+	MfaToken string
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	_ "gorm.io/gorm"
+)
+
+// @Model
+type SigningKey struct {
+	// RFC 7638 thumbprint of the public key, the kid header of the tokens it signs
+	Kid       string `gorm:"type:varchar(64);primaryKey;column:kid" json:"kid"`
+	Algorithm string `gorm:"type:varchar(16);not null;column:algorithm" json:"algorithm"`
+	// PKCS #8, encrypted
+	PrivateKey []byte `gorm:"type:bytea;not null;column:private_key" json:"-"`
+	// signs from then on, until the next key activates
+	ActivatesAt time.Time `gorm:"not null;column:activates_at" json:"activates_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (SigningKey) TableName() string {
+	return "signing_keys"
+}
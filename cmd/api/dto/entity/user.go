@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	_ "gorm.io/gorm"
 )
 
@@ -9,6 +11,12 @@ type User struct {
 	BaseEntity
 	Email    string `gorm:"unique type:varchar(255);not null;column:email" json:"email"`
 	Password string `gorm:"type:varchar(255);not null;column:password_hash" json:"password"`
+	// nil until the email is verified
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at" json:"email_verified_at"`
+	// base32 TOTP secret, pending until TotpEnabledAt is set
+	TotpSecret    string     `gorm:"type:varchar(64);column:totp_secret" json:"-"`
+	TotpEnabledAt *time.Time `gorm:"column:totp_enabled_at" json:"totp_enabled_at"`
+	TotpLastStep  *int64     `gorm:"column:totp_last_step" json:"-"`
 }
 
 /*
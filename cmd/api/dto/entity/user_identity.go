@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	_ "gorm.io/gorm"
+)
+
+// @Model
+type UserIdentity struct {
+	BaseEntity
+	UserId      uint       `gorm:"type:int;not null;column:user_id" json:"user_id"`
+	Provider    string     `gorm:"type:varchar(64);not null;column:provider" json:"provider"`
+	Subject     string     `gorm:"type:varchar(255);not null;column:subject" json:"subject"`
+	Email       string     `gorm:"type:varchar(255);not null;column:email" json:"email"`
+	LastLoginAt *time.Time `gorm:"column:last_login_at" json:"last_login_at"`
+	// whether the provider verified Email on this login, not stored
+	EmailVerified bool `gorm:"-" json:"-"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	_ "gorm.io/gorm"
+)
+
+// @Model
+type UserRecoveryCode struct {
+	BaseEntity
+	UserId   uint       `gorm:"type:int;not null;column:user_id" json:"user_id"`
+	CodeHash string     `gorm:"type:varchar(64);not null;column:code_hash" json:"-"`
+	UsedAt   *time.Time `gorm:"column:used_at" json:"used_at"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (UserRecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+// TotpEnrollment is the pending TOTP secret of a user, to add to an authenticator app.
+type TotpEnrollment struct {
+	Secret string
+	// otpauth://totp/... URI of the secret, the content of the QR code
+	URI string
+	// PNG of the QR code of URI
+	QRCode []byte
+}
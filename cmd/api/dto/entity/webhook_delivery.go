@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	_ "gorm.io/gorm"
+)
+
+const (
+	WebhookDeliveryPending    = "pending"
+	WebhookDeliveryProcessing = "processing"
+	WebhookDeliveryDelivered  = "delivered"
+	WebhookDeliveryDead       = "dead"
+)
+
+// @Model
+type WebhookDelivery struct {
+	UpdateEntity
+	ProjectId     uint       `gorm:"type:int;not null;column:project_id" json:"project_id"`
+	Project       Project    `json:"-"`
+	WebhookId     *uint      `gorm:"type:int;column:webhook_id" json:"webhook_id"`
+	PostId        *uint      `gorm:"type:int;column:post_id" json:"post_id"`
+	Event         string     `gorm:"type:varchar(50);not null;column:event" json:"event"` // 'post.created', 'post.published', 'post.updated', 'post.deleted'
+	Provider      string     `gorm:"type:varchar(255);not null;column:provider" json:"provider"`
+	Url           string     `gorm:"type:text;not null;column:url" json:"url"`
+	Payload       string     `gorm:"type:text;not null;column:payload" json:"payload"`
+	Status        string     `gorm:"type:varchar(20);not null;column:status" json:"status"` // 'pending', 'processing', 'delivered', 'dead'
+	Attempts      int        `gorm:"type:int;not null;column:attempts" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null;column:next_attempt_at" json:"next_attempt_at"`
+	LastError     string     `gorm:"type:text;column:last_error" json:"last_error"`
+	DeliveredAt   *time.Time `gorm:"column:delivered_at" json:"delivered_at"`
+
+	AttemptLogs []WebhookDeliveryAttempt `gorm:"foreignKey:DeliveryId" json:"attempt_logs,omitempty"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	_ "gorm.io/gorm"
+)
+
+// @Model
+type WebhookDeliveryAttempt struct {
+	BaseEntity
+	DeliveryId     uint   `gorm:"type:int;not null;column:delivery_id" json:"delivery_id"`
+	Attempt        int    `gorm:"type:int;not null;column:attempt" json:"attempt"`
+	RequestPayload string `gorm:"type:text;not null;column:request_payload" json:"request_payload"`
+	ResponseStatus *int   `gorm:"type:int;column:response_status" json:"response_status"` // null when the request never got a response
+	ResponseBody   string `gorm:"type:text;column:response_body" json:"response_body"`
+	LatencyMs      int64  `gorm:"type:int;not null;column:latency_ms" json:"latency_ms"`
+	Error          string `gorm:"type:text;column:error" json:"error"`
+}
+
+/*
+	for filtering field use like this for [carts] table:
+	- carts.quantity -> even for current table filtering, always call the table name like this
+	- products.name -> filter using products table with field name ->
+	remember to not using struct field -> always use real tables and field name
+*/
+
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
@@ -0,0 +1,24 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type AddOrgMemberRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	Role  string `json:"role" validate:"required,oneof=owner editor viewer"`
+}
+
+func (r AddOrgMemberRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *AddOrgMemberRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
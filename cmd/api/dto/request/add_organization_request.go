@@ -0,0 +1,24 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type AddOrganizationRequest struct {
+	Name         string `json:"name" validate:"required,max=255"`
+	BillingEmail string `json:"billing_email" validate:"omitempty,email,max=255"`
+}
+
+func (r AddOrganizationRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *AddOrganizationRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
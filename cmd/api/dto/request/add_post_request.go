@@ -2,6 +2,7 @@ package request
 
 import (
 	"encoding/json"
+	"time"
 )
 
 type AddPostRequest struct {
@@ -9,7 +10,9 @@ type AddPostRequest struct {
 	Title     string `json:"title" validate:"required,max=255"`
 	Content   string `json:"content" validate:"required"`
 	Category  string `json:"category" validate:"max=50"`
-	Status    string `json:"status" validate:"max=20"`
+	Status    string `json:"status" validate:"omitempty,oneof=draft published"` // default 'draft'
+	// only for drafts, e.g. "2025-01-31T09:00:00+07:00"
+	PublishAt *time.Time `json:"publish_at"`
 }
 
 func (r AddPostRequest) Marshal() ([]byte, error) {
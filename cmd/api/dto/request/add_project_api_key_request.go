@@ -0,0 +1,24 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type AddProjectApiKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=posts:read posts:write"`
+}
+
+func (r AddProjectApiKeyRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *AddProjectApiKeyRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
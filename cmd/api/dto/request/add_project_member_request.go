@@ -0,0 +1,24 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type AddProjectMemberRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	Role  string `json:"role" validate:"required,oneof=owner editor viewer"`
+}
+
+func (r AddProjectMemberRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *AddProjectMemberRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
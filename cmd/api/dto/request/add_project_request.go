@@ -5,9 +5,22 @@ import (
 )
 
 type AddProjectRequest struct {
-	Name       string `json:"name" validate:"required,max=255"`
-	Slug       string `json:"slug" validate:"required,max=255"`
-	WebhookUrl string `json:"webhook_url"`
+	Name string `json:"name" validate:"required,max=255"`
+	Slug string `json:"slug" validate:"required,max=255"`
+	// organization owning the project, defaults to the personal org of the caller.
+	// Only used on create
+	OrgId uint `json:"org_id"`
+	// optional first webhook, subscribed to every event. Only used on create,
+	// manage webhooks afterwards with /v1/projects/{id}/webhooks
+	WebhookUrl      string `json:"webhook_url" validate:"omitempty,url"`
+	WebhookProvider string `json:"webhook_provider" validate:"omitempty,webhook_provider"` // e.g., 'generic', 'discord', 'slack', 'teams', 'telegram', 'mattermost', 'google_chat'
+	// browser origins allowed to use the public API, e.g. ["https://app.example.com"] or ["*"].
+	// Left unchanged on update when omitted
+	AllowedOrigins []string `json:"allowed_origins" validate:"omitempty,dive,cors_origin"`
+	// hosted changelog page settings, the page title defaults to the project name
+	PageTitle   string `json:"page_title" validate:"omitempty,max=255"`
+	LogoUrl     string `json:"logo_url" validate:"omitempty,url"`
+	AccentColor string `json:"accent_color" validate:"omitempty,hexcolor,max=7"` // e.g., '#4f46e5'
 }
 
 func (r AddProjectRequest) Marshal() ([]byte, error) {
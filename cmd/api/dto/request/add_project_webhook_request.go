@@ -0,0 +1,28 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type AddProjectWebhookRequest struct {
+	Url      string   `json:"url" validate:"required,url"`
+	Provider string   `json:"provider" validate:"omitempty,webhook_provider"`
+	Enabled  *bool    `json:"enabled"`
+	Events   []string `json:"events" validate:"required,min=1,dive,oneof=post.created post.published post.unpublished post.updated post.deleted"`
+	// e.g. {"text": {{json .Post.Title}}, "project": {{json .Project.Slug}}}
+	PayloadTemplate string `json:"payload_template" validate:"omitempty,webhook_template"`
+}
+
+func (r AddProjectWebhookRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *AddProjectWebhookRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
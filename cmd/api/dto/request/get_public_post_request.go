@@ -0,0 +1,40 @@
+package request
+
+const (
+	defaultPublicPageSize = 20
+	maxPublicPageSize     = 100
+)
+
+// @Model
+type GetPublicPostRequest struct {
+	Page     int    `url:"page" validate:"omitempty,min=1"`              // default 1
+	PageSize int    `url:"page_size" validate:"omitempty,min=1,max=100"` // default 20
+	Category string `url:"category"`
+}
+
+// Pagination returns the request as a PaginationRequest, latest published posts first. Public
+// requests can't choose the order or search fields, those go straight into SQL.
+func (r GetPublicPostRequest) Pagination() PaginationRequest {
+	page := r.Page
+
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := r.PageSize
+
+	if pageSize < 1 {
+		pageSize = defaultPublicPageSize
+	}
+
+	if pageSize > maxPublicPageSize {
+		pageSize = maxPublicPageSize
+	}
+
+	return PaginationRequest{
+		Page:     page,
+		PageSize: pageSize,
+		OrderBy:  "posts.published_at",
+		Sort:     "DESC",
+	}
+}
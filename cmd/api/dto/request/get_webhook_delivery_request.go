@@ -0,0 +1,7 @@
+package request
+
+// @Model
+type GetWebhookDeliveryRequest struct {
+	PaginationRequest
+	Status string `url:"status"` // 'pending', 'processing', 'delivered', 'dead'
+}
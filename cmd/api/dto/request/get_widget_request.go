@@ -0,0 +1,24 @@
+package request
+
+const defaultWidgetLimit = 5
+
+// @Model
+type GetWidgetRequest struct {
+	Limit int `url:"limit" validate:"omitempty,min=1,max=20"` // default 5
+	// last time the user opened the widget (RFC3339), posts after it are unseen
+	Since string `url:"since" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// Posts returns the request for the latest posts shown in the widget.
+func (r GetWidgetRequest) Posts() GetPublicPostRequest {
+	limit := r.Limit
+
+	if limit < 1 {
+		limit = defaultWidgetLimit
+	}
+
+	return GetPublicPostRequest{
+		Page:     1,
+		PageSize: limit,
+	}
+}
@@ -0,0 +1,25 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+// OidcCallbackRequest is the query of the redirect back from the provider.
+type OidcCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+func (r OidcCallbackRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *OidcCallbackRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+// PatchPostRequest only updates the fields that are sent.
+type PatchPostRequest struct {
+	Title    *string `json:"title" validate:"omitnil,min=1,max=255"`
+	Content  *string `json:"content" validate:"omitnil,min=1"`
+	Category *string `json:"category" validate:"omitnil,max=50"`
+}
+
+func (r PatchPostRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *PatchPostRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
+
+// Updates returns the sent fields keyed by column name.
+func (r PatchPostRequest) Updates() map[string]any {
+	updates := map[string]any{}
+
+	if r.Title != nil {
+		updates["title"] = *r.Title
+	}
+
+	if r.Content != nil {
+		updates["content"] = *r.Content
+	}
+
+	if r.Category != nil {
+		updates["category"] = *r.Category
+	}
+
+	return updates
+}
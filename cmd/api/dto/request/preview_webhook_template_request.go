@@ -0,0 +1,24 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type PreviewWebhookTemplateRequest struct {
+	PayloadTemplate string `json:"payload_template" validate:"required"`
+	Event           string `json:"event" validate:"omitempty,oneof=post.created post.published post.unpublished post.updated post.deleted"` // default 'post.created'
+}
+
+func (r PreviewWebhookTemplateRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *PreviewWebhookTemplateRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
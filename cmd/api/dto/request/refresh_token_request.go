@@ -0,0 +1,24 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+// RefreshTokenRequest is the body of refresh and logout.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func (r RefreshTokenRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *RefreshTokenRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
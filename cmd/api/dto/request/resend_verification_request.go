@@ -0,0 +1,23 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+func (r ResendVerificationRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *ResendVerificationRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
@@ -0,0 +1,26 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type ResetPasswordRequest struct {
+	// the token of the password reset email
+	Token string `json:"token" validate:"required"`
+	// bcrypt ignores bytes after the 72nd
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+func (r ResetPasswordRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *ResetPasswordRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
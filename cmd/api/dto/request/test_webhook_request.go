@@ -0,0 +1,29 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+// TestWebhookRequest targets a saved webhook with WebhookId, or an unsaved url
+// (e.g. the one about to be used as AddProjectRequest.WebhookUrl).
+type TestWebhookRequest struct {
+	WebhookId       uint   `json:"webhook_id"`
+	Url             string `json:"url" validate:"required_without=WebhookId,omitempty,url"`
+	Provider        string `json:"provider" validate:"omitempty,webhook_provider"`
+	PayloadTemplate string `json:"payload_template" validate:"omitempty,webhook_template"`
+	Event           string `json:"event" validate:"omitempty,oneof=post.created post.published post.unpublished post.updated post.deleted"` // default 'post.created'
+}
+
+func (r TestWebhookRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *TestWebhookRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
@@ -0,0 +1,24 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type TotpCodeRequest struct {
+	// a code of the authenticator app, or a recovery code where accepted
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+func (r TotpCodeRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *TotpCodeRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
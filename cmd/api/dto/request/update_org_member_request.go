@@ -0,0 +1,23 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type UpdateOrgMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner editor viewer"`
+}
+
+func (r UpdateOrgMemberRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *UpdateOrgMemberRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
@@ -0,0 +1,27 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+// UpdatePostRequest replaces the content of a post, status only changes through
+// the publish and unpublish endpoints.
+type UpdatePostRequest struct {
+	Title    string `json:"title" validate:"required,max=255"`
+	Content  string `json:"content" validate:"required"`
+	Category string `json:"category" validate:"max=50"`
+}
+
+func (r UpdatePostRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *UpdatePostRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
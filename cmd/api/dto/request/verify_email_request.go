@@ -0,0 +1,24 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type VerifyEmailRequest struct {
+	// the token of the verification email
+	Token string `json:"token" validate:"required"`
+}
+
+func (r VerifyEmailRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *VerifyEmailRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
@@ -0,0 +1,26 @@
+package request
+
+import (
+	"encoding/json"
+)
+
+type VerifyMfaRequest struct {
+	// mfa_token of the login response
+	MfaToken string `json:"mfa_token" validate:"required"`
+	// a code of the authenticator app or a recovery code
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+func (r VerifyMfaRequest) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *VerifyMfaRequest) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
@@ -24,7 +24,13 @@ func (r *LoginResponse) Unmarshal(data []byte) error {
 }
 
 type LoginData struct {
-	ID    int    `json:"id"`
-	Token string `json:"token"`
-	Email string `json:"email"`
+	ID           int    `json:"id"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// seconds until token expires
+	ExpiresIn int64  `json:"expires_in"`
+	Email     string `json:"email"`
+	// the user has 2FA: the tokens are empty, send mfa_token with a code to /auth/2fa/verify
+	MfaRequired bool   `json:"mfa_required"`
+	MfaToken    string `json:"mfa_token,omitempty"`
 }
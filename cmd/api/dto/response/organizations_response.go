@@ -0,0 +1,27 @@
+package response
+
+import "github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+
+// @Model
+type OrganizationsResponse struct {
+	BaseResponse
+	Organizations []entity.Organization `json:"organizations"`
+}
+
+// @Model
+type OrganizationResponse struct {
+	BaseResponse
+	Organization entity.Organization `json:"organization"`
+}
+
+// @Model
+type OrgMembersResponse struct {
+	BaseResponse
+	Members []entity.OrgMember `json:"members"`
+}
+
+// @Model
+type OrgMemberResponse struct {
+	BaseResponse
+	Member entity.OrgMember `json:"member"`
+}
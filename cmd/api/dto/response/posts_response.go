@@ -1,16 +1,33 @@
 package response
 
-import "github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+import (
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+)
+
+// Post is a post with its content rendered as sanitized HTML.
+// @Model
+type Post struct {
+	entity.Post
+	ContentHTML string `json:"content_html"`
+}
+
+func NewPost(post entity.Post) Post {
+	return Post{
+		Post:        post,
+		ContentHTML: markdown.ToHTML(post.Content),
+	}
+}
 
 // @Model
 type PostsResponse struct {
 	BaseResponse
-	Posts []entity.Post `json:"posts"`
+	Posts []Post `json:"posts"`
 	Pagination PaginationMetadata `json:"pagination"`
 }
 
 // @Model
 type PostResponse struct {
 	BaseResponse
-	Post entity.Post `json:"post"`
+	Post Post `json:"post"`
 }
\ No newline at end of file
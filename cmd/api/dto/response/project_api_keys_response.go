@@ -0,0 +1,15 @@
+package response
+
+import "github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+
+// @Model
+type ProjectApiKeysResponse struct {
+	BaseResponse
+	Keys []entity.ProjectApiKey `json:"keys"`
+}
+
+// @Model
+type ProjectApiKeyResponse struct {
+	BaseResponse
+	Key entity.ProjectApiKey `json:"key"`
+}
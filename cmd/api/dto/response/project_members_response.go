@@ -0,0 +1,15 @@
+package response
+
+import "github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+
+// @Model
+type ProjectMembersResponse struct {
+	BaseResponse
+	Members []entity.ProjectMember `json:"members"`
+}
+
+// @Model
+type ProjectMemberResponse struct {
+	BaseResponse
+	Member entity.ProjectMember `json:"member"`
+}
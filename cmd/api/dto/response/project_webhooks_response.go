@@ -0,0 +1,15 @@
+package response
+
+import "github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+
+// @Model
+type ProjectWebhooksResponse struct {
+	BaseResponse
+	Webhooks []entity.ProjectWebhook `json:"webhooks"`
+}
+
+// @Model
+type ProjectWebhookResponse struct {
+	BaseResponse
+	Webhook entity.ProjectWebhook `json:"webhook"`
+}
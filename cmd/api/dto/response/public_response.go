@@ -0,0 +1,72 @@
+package response
+
+import (
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+)
+
+// PublicProject is the part of a project that is visible without authentication.
+// @Model
+type PublicProject struct {
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	PageTitle   string     `json:"page_title"`
+	LogoUrl     string     `json:"logo_url"`
+	AccentColor string     `json:"accent_color"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+// PublicPost is the part of a post that is visible without authentication.
+// @Model
+type PublicPost struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Content     string     `json:"content"`
+	ContentHTML string     `json:"content_html"`
+	Category    string     `json:"category"`
+	PublishedAt time.Time  `json:"published_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+func NewPublicProject(project entity.Project) PublicProject {
+	return PublicProject{
+		Name:        project.Name,
+		Slug:        project.Slug,
+		PageTitle:   project.PageTitle,
+		LogoUrl:     project.LogoUrl,
+		AccentColor: project.AccentColor,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
+
+func NewPublicPost(post entity.Post) PublicPost {
+	return PublicPost{
+		ID:          post.ID,
+		Title:       post.Title,
+		Content:     post.Content,
+		ContentHTML: markdown.ToHTML(post.Content),
+		Category:    post.Category,
+		PublishedAt: post.PublishedDate(),
+		CreatedAt:   post.CreatedAt,
+		UpdatedAt:   post.UpdatedAt,
+	}
+}
+
+// @Model
+type PublicProjectResponse struct {
+	BaseResponse
+	Project PublicProject `json:"project"`
+}
+
+// @Model
+type PublicPostsResponse struct {
+	BaseResponse
+	Project    PublicProject      `json:"project"`
+	Posts      []PublicPost       `json:"posts"`
+	Pagination PaginationMetadata `json:"pagination"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"encoding/json"
+)
+
+type TokenResponse struct {
+	BaseResponse
+	Data TokenData `json:"data"`
+}
+
+func (r TokenResponse) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *TokenResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
+
+type TokenData struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// seconds until token expires
+	ExpiresIn int64 `json:"expires_in"`
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"encoding/json"
+)
+
+type TotpEnrollmentResponse struct {
+	BaseResponse
+	Data TotpEnrollmentData `json:"data"`
+}
+
+func (r TotpEnrollmentResponse) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *TotpEnrollmentResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
+
+type TotpEnrollmentData struct {
+	// base32 secret, for apps that can't scan the QR code
+	Secret     string `json:"secret"`
+	OtpauthUrl string `json:"otpauth_url"`
+	// data:image/png;base64 URL of the QR code of otpauth_url
+	QrCode string `json:"qr_code"`
+}
+
+type RecoveryCodesResponse struct {
+	BaseResponse
+	// each code works once instead of a TOTP code, they are only shown here
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (r RecoveryCodesResponse) Marshal() ([]byte, error) {
+	marshal, err := json.Marshal(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal, nil
+}
+
+func (r *RecoveryCodesResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
@@ -0,0 +1,16 @@
+package response
+
+import "github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+
+// @Model
+type WebhookDeliveriesResponse struct {
+	BaseResponse
+	Deliveries []entity.WebhookDelivery `json:"deliveries"`
+	Pagination PaginationMetadata       `json:"pagination"`
+}
+
+// @Model
+type WebhookDeliveryResponse struct {
+	BaseResponse
+	Delivery entity.WebhookDelivery `json:"delivery"`
+}
@@ -0,0 +1,7 @@
+package response
+
+// @Model
+type WebhookPreviewResponse struct {
+	BaseResponse
+	Payload string `json:"payload"`
+}
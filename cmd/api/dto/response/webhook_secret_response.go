@@ -0,0 +1,7 @@
+package response
+
+// @Model
+type WebhookSecretResponse struct {
+	BaseResponse
+	WebhookSecret string `json:"webhook_secret"`
+}
@@ -0,0 +1,18 @@
+package response
+
+// @Model
+type WebhookTestResult struct {
+	Success    bool   `json:"success"`
+	Url        string `json:"url"`
+	Payload    string `json:"payload"`
+	StatusCode int    `json:"status_code"`
+	LatencyMs  int64  `json:"latency_ms"`
+	Body       string `json:"body"`
+	Error      string `json:"error"`
+}
+
+// @Model
+type WebhookTestResponse struct {
+	BaseResponse
+	Result WebhookTestResult `json:"result"`
+}
@@ -0,0 +1,10 @@
+package response
+
+// @Model
+type WidgetResponse struct {
+	BaseResponse
+	Project PublicProject `json:"project"`
+	Posts   []PublicPost  `json:"posts"`
+	// published posts newer than the since parameter, every published post without it
+	UnseenCount int64 `json:"unseen_count"`
+}
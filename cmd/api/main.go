@@ -13,25 +13,35 @@
 // @securitydefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
-// @description Type "Bearer" followed by a space and JWT token.
+// @description Type "Bearer" followed by a space and JWT token, or "ApiKey" followed by a space and a project API key.
 
 package main
 
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/ariefzainuri96/go-logstream/cmd/api/controller"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/docs"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
 	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"github.com/ariefzainuri96/go-logstream/internal/interfaces"
+	"github.com/ariefzainuri96/go-logstream/internal/jwtkeys"
 	"github.com/ariefzainuri96/go-logstream/internal/logger"
+	"github.com/ariefzainuri96/go-logstream/internal/loginlimit"
+	"github.com/ariefzainuri96/go-logstream/internal/mailer"
 	"github.com/ariefzainuri96/go-logstream/internal/service"
+	"github.com/ariefzainuri96/go-logstream/internal/sso"
 	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"github.com/ariefzainuri96/go-logstream/internal/webhook"
 	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
@@ -52,10 +62,171 @@ func loadConfig() controller.Config {
 		ttl = time.Duration(s) * time.Second
 	}
 
+	trustProxyHeaders, _ := strconv.ParseBool(os.Getenv("TRUST_PROXY_HEADERS"))
+
 	return controller.Config{
-		HTTPPort:    httpPort,
-		ShutdownTTL: ttl,
+		HTTPPort:          httpPort,
+		ShutdownTTL:       ttl,
+		PublicBaseURL:     strings.TrimSuffix(os.Getenv("PUBLIC_BASE_URL"), "/"),
+		TrustProxyHeaders: trustProxyHeaders,
+	}
+}
+
+// positiveIntEnv parses the env var name, ok is false when it is unset, invalid or not
+// positive so the caller keeps its default: a zero interval panics a ticker and a zero
+// rotation period adds a key on every check.
+func positiveIntEnv(name string) (int, bool) {
+	var n int
+
+	if _, err := fmt.Sscanf(os.Getenv(name), "%d", &n); err != nil || n <= 0 {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// positiveSecondsEnv is the env var name in seconds, def unless positiveIntEnv is ok.
+func positiveSecondsEnv(name string, def time.Duration) time.Duration {
+	if s, ok := positiveIntEnv(name); ok {
+		return time.Duration(s) * time.Second
+	}
+
+	return def
+}
+
+// loadWebhookWorkerConfig loads webhook delivery worker config from environment
+func loadWebhookWorkerConfig() service.WebhookWorkerConfig {
+	cfg := service.WebhookWorkerConfig{
+		PollInterval: 5 * time.Second,
+		BatchSize:    20,
+		MaxAttempts:  8,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   6 * time.Hour,
+		StaleAfter:   5 * time.Minute,
+	}
+
+	cfg.PollInterval = positiveSecondsEnv("WEBHOOK_POLL_INTERVAL", cfg.PollInterval)
+
+	if n, ok := positiveIntEnv("WEBHOOK_MAX_ATTEMPTS"); ok {
+		cfg.MaxAttempts = n
 	}
+
+	return cfg
+}
+
+// loadPostSchedulerConfig loads scheduled publishing config from environment
+func loadPostSchedulerConfig() service.PostSchedulerConfig {
+	cfg := service.PostSchedulerConfig{
+		PollInterval: 30 * time.Second,
+		BatchSize:    50,
+	}
+
+	cfg.PollInterval = positiveSecondsEnv("POST_SCHEDULER_INTERVAL", cfg.PollInterval)
+
+	return cfg
+}
+
+// loadSigningKeyConfig loads the JWT signing keys config from environment
+func loadSigningKeyConfig() jwtkeys.Config {
+	cfg := jwtkeys.DefaultConfig()
+	cfg.Secret = []byte(strings.TrimSpace(os.Getenv("SECRET_KEY")))
+
+	if v := os.Getenv("JWT_SIGNING_ALG"); v != "" {
+		cfg.Algorithm = v
+	}
+	if v := os.Getenv("JWT_ISSUER"); v != "" {
+		cfg.Issuer = v
+	}
+	if v := os.Getenv("JWT_AUDIENCE"); v != "" {
+		cfg.Audience = v
+	}
+	if d, ok := positiveIntEnv("JWT_KEY_ROTATION_DAYS"); ok {
+		cfg.RotateEvery = time.Duration(d) * 24 * time.Hour
+	}
+
+	return cfg
+}
+
+// loadAuthConfig loads the mailer, links of the auth emails, login throttling and the
+// OIDC providers from environment
+func loadAuthConfig(logger *zap.Logger, storage store.Storage) service.AuthConfig {
+	mailerCfg := mailer.Config{
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		From:         os.Getenv("MAIL_FROM"),
+		LogFile:      os.Getenv("MAIL_LOG_FILE"),
+		Production:   os.Getenv("APP_ENV") == "production",
+	}
+
+	if v := os.Getenv("SMTP_PORT"); v != "" {
+		fmt.Sscanf(v, "%d", &mailerCfg.SMTPPort)
+	}
+
+	emailer, err := mailer.New(mailerCfg, logger)
+
+	if err != nil {
+		logger.Fatal("Error configuring the mailer", zap.Error(err))
+	}
+
+	requireVerifiedEmail, _ := strconv.ParseBool(os.Getenv("REQUIRE_VERIFIED_EMAIL"))
+
+	// attempts are counted in the process unless replicas have to share them
+	var loginAttempts interfaces.ILoginAttempt = loginlimit.NewMemory()
+
+	if os.Getenv("LOGIN_LIMIT_BACKEND") == "postgres" {
+		loginAttempts = storage.ILoginAttempt
+	}
+
+	return service.AuthConfig{
+		Mailer:               emailer,
+		PasswordResetURL:     os.Getenv("PASSWORD_RESET_URL"),
+		EmailVerificationURL: os.Getenv("EMAIL_VERIFICATION_URL"),
+		RequireVerifiedEmail: requireVerifiedEmail,
+		LoginLimiter:         loginlimit.New(loginAttempts, loginlimit.DefaultConfig()),
+		SSO:                  sso.New(loadSSOConfig(logger)),
+	}
+}
+
+// loadSSOConfig loads the OIDC providers listed in OIDC_PROVIDERS, each from its
+// OIDC_<NAME>_* variables
+func loadSSOConfig(logger *zap.Logger) sso.Config {
+	cfg := sso.Config{
+		Secret:     []byte(strings.TrimSpace(os.Getenv("SECRET_KEY"))),
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		provider := sso.ProviderConfig{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+
+		if provider.RedirectURL == "" && os.Getenv("PUBLIC_BASE_URL") != "" {
+			provider.RedirectURL = strings.TrimSuffix(os.Getenv("PUBLIC_BASE_URL"), "/") + "/v1/auth/oidc/" + name + "/callback"
+		}
+
+		if provider.Issuer == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+			logger.Error("⚠️ OIDC provider skipped, it needs an issuer, a client id and a redirect url", zap.String("Provider", name))
+			continue
+		}
+
+		cfg.Providers = append(cfg.Providers, provider)
+	}
+
+	return cfg
 }
 
 func main() {
@@ -91,13 +262,28 @@ func main() {
 	// WaitGroup to wait for servers to stop
 	var wg sync.WaitGroup
 
-	store := store.NewStorage(gorm, logger)
-	service := service.NewService(store, logger)
+	store := store.NewStorage(gorm, logger, loadSigningKeyConfig())
+
+	// tokens can't be signed before the first key exists
+	if err := store.Keys.Init(ctx); err != nil {
+		logger.Fatal("Error loading JWT signing keys", zap.Error(err))
+	}
+
+	webhookWorker := service.NewWebhookWorker(store, logger, loadWebhookWorkerConfig())
+	postScheduler := service.NewPostScheduler(store, logger, loadPostSchedulerConfig())
+	service := service.NewService(store, logger, loadAuthConfig(logger, store))
+
+	validate := validator.New()
+	validate.RegisterValidation("webhook_provider", webhook.ValidateProvider)
+	validate.RegisterValidation("webhook_template", webhook.ValidateTemplate)
+	validate.RegisterValidation("cors_origin", middleware.ValidateOrigin)
 
 	application := &controller.Application{
 		Config:    cfg,
 		Service:   service,
-		Validator: validator.New(),
+		Validator: validate,
+		Keys:      store.Keys,
+		Logger:    logger,
 	}
 
 	// run server
@@ -112,6 +298,39 @@ func main() {
 		}
 	}()
 
+	// run webhook delivery worker
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := webhookWorker.Run(ctx); err != nil {
+			logger.Error("webhook worker stopped with error", zap.Error(err))
+		} else {
+			logger.Info("webhook worker stopped")
+		}
+	}()
+
+	// run signing key rotation
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := store.Keys.Run(ctx); err != nil {
+			logger.Error("signing key rotation stopped with error", zap.Error(err))
+		} else {
+			logger.Info("signing key rotation stopped")
+		}
+	}()
+
+	// run scheduled publishing
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := postScheduler.Run(ctx); err != nil {
+			logger.Error("post scheduler stopped with error", zap.Error(err))
+		} else {
+			logger.Info("post scheduler stopped")
+		}
+	}()
+
 	// ---------------------------------------------------------
 	// Graceful shutdown on OS signals
 	// ---------------------------------------------------------
@@ -4,34 +4,98 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"os"
 	"strings"
 
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/utils"
 	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
 )
 
 // Key to store user information in context
 type contextKey string
 const UserContextKey contextKey = "user"
 
+// Key to store the project API key the request is authenticated with
+const ApiKeyContextKey contextKey = "api_key"
+
+// ApiKeyResolver returns the unrevoked project API key matching key,
+// gorm.ErrRecordNotFound when there is none.
+type ApiKeyResolver func(ctx context.Context, key string) (entity.ProjectApiKey, error)
+
+// TokenParser verifies a JWT and returns its claims.
+type TokenParser func(token string) (jwt.MapClaims, error)
+
+// TokenRevocationChecker reports whether the access token with the jti claim was
+// revoked by a logout or a refresh token reuse.
+type TokenRevocationChecker func(ctx context.Context, jti string) (bool, error)
+
+// Authenticator are the lookups of the Authentication middleware.
+type Authenticator struct {
+	// nil rejects "Authorization: ApiKey" requests
+	ApiKeys ApiKeyResolver
+	// nil rejects "Authorization: Bearer" requests
+	ParseToken     TokenParser
+	IsTokenRevoked TokenRevocationChecker
+}
+
 // Function to get user data from request
 func GetUserFromContext(r *http.Request) (map[string]any, bool) {
 	userData, ok := r.Context().Value(UserContextKey).(map[string]any)
 	return userData, ok
 }
 
+// UserIdFromContext returns the id of the user authenticated by the Authentication
+// middleware, for layers that only get the request context.
+func UserIdFromContext(ctx context.Context) (uint, bool) {
+	userData, ok := ctx.Value(UserContextKey).(map[string]any)
+
+	if !ok {
+		return 0, false
+	}
+
+	userId, ok := userData["user_id"].(uint)
+	return userId, ok
+}
+
+// ApiKeyFromContext returns the project API key the request is authenticated with.
+// Such requests carry no user, GetUserFromContext and UserIdFromContext fail for them.
+func ApiKeyFromContext(ctx context.Context) (entity.ProjectApiKey, bool) {
+	apiKey, ok := ctx.Value(ApiKeyContextKey).(entity.ProjectApiKey)
+	return apiKey, ok
+}
+
 /*
 	This Authentication middleware usage is for route
 
-	mux.Handle("/v1/product/", middleware.Authentication(http.StripPrefix("/v1/product", app.ProductRouter())))
-*/
-func Authentication(next http.Handler) http.Handler {
-	jwtSecret := os.Getenv("SECRET_KEY")
+	mux.Handle("/v1/product/", middleware.Authentication(auth, http.StripPrefix("/v1/product", app.ProductRouter())))
 
+	It accepts "Authorization: Bearer <jwt>" of a user, verified by auth.ParseToken, which must carry an unrevoked jti,
+	and "Authorization: ApiKey <key>" of a project API key when auth.ApiKeys isn't nil.
+*/
+func Authentication(auth Authenticator, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 
+		if key, ok := strings.CutPrefix(authHeader, "ApiKey "); ok && auth.ApiKeys != nil {
+			apiKey, err := auth.ApiKeys(r.Context(), strings.TrimSpace(key))
+
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				utils.RespondError(w, http.StatusUnauthorized, "Invalid API Key")
+				return
+			}
+
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ApiKeyContextKey, apiKey)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
 			utils.RespondError(w, http.StatusUnauthorized, "Missing Authorization Header")			
 			return
@@ -39,22 +103,41 @@ func Authentication(next http.Handler) http.Handler {
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("unexpected signing method")
-			}
-			return []byte(jwtSecret), nil
-		})
+		if auth.ParseToken == nil {
+			utils.RespondError(w, http.StatusUnauthorized, "Invalid Token")
+			return
+		}
+
+		claims, err := auth.ParseToken(tokenString)
 
-		if err != nil || !token.Valid {
+		if err != nil {
 			utils.RespondError(w, http.StatusUnauthorized, "Invalid Token")
 			return
 		}
 
-		// Extract claims from the token
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			utils.RespondError(w, http.StatusUnauthorized, "Invalid Token Claims")			
+		// tokens with a purpose, e.g. email verification, aren't access tokens
+		if _, ok := claims["purpose"]; ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Invalid Token")
+			return
+		}
+
+		// tokens without jti were issued before they could be revoked
+		jti, _ := claims["jti"].(string)
+
+		if jti == "" || auth.IsTokenRevoked == nil {
+			utils.RespondError(w, http.StatusUnauthorized, "Invalid Token")
+			return
+		}
+
+		revoked, err := auth.IsTokenRevoked(r.Context(), jti)
+
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		if revoked {
+			utils.RespondError(w, http.StatusUnauthorized, "Token revoked, please re login!")
 			return
 		}
 
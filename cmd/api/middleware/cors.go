@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// OriginAllowed reports whether origin is in allowed, '*' allows any origin.
+func OriginAllowed(origin string, allowed []string) bool {
+	if origin == "" {
+		return false
+	}
+
+	return slices.Contains(allowed, "*") || slices.Contains(allowed, origin)
+}
+
+/*
+PublicCORS sets the CORS headers of a public response for a project with the
+allowed origins. Preflight requests (OPTIONS) are answered with 204.
+
+	if middleware.PublicCORS(w, r, project.AllowedOrigins) {
+		return
+	}
+*/
+func PublicCORS(w http.ResponseWriter, r *http.Request, allowed []string) (handled bool) {
+	// public responses are cached, so they must vary on the origin
+	w.Header().Add("Vary", "Origin")
+
+	origin := r.Header.Get("Origin")
+
+	if OriginAllowed(origin, allowed) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
+	}
+
+	if r.Method != http.MethodOptions {
+		return false
+	}
+
+	if OriginAllowed(origin, allowed) {
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "If-None-Match")
+		w.Header().Set("Access-Control-Max-Age", "600")
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// ValidateOrigin is the "cors_origin" validator tag, it accepts '*' or an origin like
+// https://app.example.com (scheme and host, without path).
+func ValidateOrigin(fl validator.FieldLevel) bool {
+	origin := fl.Field().String()
+
+	if origin == "*" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.Path == "" && u.RawQuery == "" && u.Fragment == "" && u.User == nil
+}
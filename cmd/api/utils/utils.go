@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"	
 )
@@ -34,4 +38,36 @@ func RespondError(w http.ResponseWriter, status int, message string) {
 	}
 	// Use writeJSON internally
 	WriteJSON(w, status, errorResp)
-}
\ No newline at end of file
+}
+
+// WriteCachedJSON is WriteCached for a JSON response.
+func WriteCachedJSON(w http.ResponseWriter, r *http.Request, data interface{}) {
+	respBytes, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("ERROR: Failed to marshal response data: %v", err)
+		RespondError(w, http.StatusInternalServerError, "Internal Server Error: Failed to serialize response.")
+		return
+	}
+
+	WriteCached(w, r, "application/json", respBytes)
+}
+
+// WriteCached writes body with ETag and Cache-Control headers, and answers If-None-Match
+// with 304 Not Modified. Cache-Control is public unless already set.
+//
+// There is no Last-Modified: deleting or unpublishing a post doesn't leave a newer
+// timestamp behind, If-Modified-Since would answer 304 with a stale body.
+func WriteCached(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+	// the ETag follows the body, so it also changes when a post is deleted
+	sum := sha256.Sum256(body)
+
+	w.Header().Set("Content-Type", contentType)
+	if w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", "public, max-age=60")
+	}
+
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, sum[:16]))
+
+	// a zero modtime skips Last-Modified and If-Modified-Since
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(body))
+}
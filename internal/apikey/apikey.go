@@ -0,0 +1,58 @@
+// Package apikey generates and checks project API keys.
+//
+// A key looks like ls_<prefix>_<secret>. The ls_<prefix> part is stored in clear to
+// find the key and to show it in key lists, only the SHA-256 hash of the whole key
+// is stored.
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+const keyPrefix = "ls_"
+
+// New returns a random key, its prefix and its hash.
+func New() (key string, prefix string, hash string, err error) {
+	b := make([]byte, 4+32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+
+	prefix = keyPrefix + hex.EncodeToString(b[:4])
+	key = prefix + "_" + hex.EncodeToString(b[4:])
+
+	return key, prefix, Hash(key), nil
+}
+
+// Hash returns the hex SHA-256 of key. Keys are random, a slow password hash isn't
+// needed.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Prefix returns the prefix of key, false when key isn't shaped like an API key.
+func Prefix(key string) (string, bool) {
+	if !strings.HasPrefix(key, keyPrefix) {
+		return "", false
+	}
+
+	prefix, secret, ok := strings.Cut(strings.TrimPrefix(key, keyPrefix), "_")
+
+	if !ok || prefix == "" || secret == "" {
+		return "", false
+	}
+
+	return keyPrefix + prefix, true
+}
+
+// Matches reports whether key hashes to hash, in constant time.
+func Matches(key string, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(hash)) == 1
+}
@@ -0,0 +1,200 @@
+// Package feed renders the published posts of a project as RSS 2.0 and Atom 1.0.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+)
+
+const (
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+	AtomContentType = "application/atom+xml; charset=utf-8"
+)
+
+// Feed is a project changelog, Posts are expected newest first.
+type Feed struct {
+	Project entity.Project
+	Posts   []entity.Post
+	// absolute url of the hosted changelog, a post page is Link/{post id}
+	Link string
+	// absolute url of the feed itself
+	Self    string
+	Updated time.Time
+}
+
+// PostID is the stable id of a post in every feed, it doesn't change when the project
+// slug or the host does.
+func PostID(post entity.Post) string {
+	return fmt.Sprintf("urn:logstream:project:%d:post:%d", post.ProjectId, post.ID)
+}
+
+func postURL(f Feed, post entity.Post) string {
+	return fmt.Sprintf("%s/%d", f.Link, post.ID)
+}
+
+func projectID(project entity.Project) string {
+	return fmt.Sprintf("urn:logstream:project:%d", project.ID)
+}
+
+func postUpdated(post entity.Post) time.Time {
+	if post.UpdatedAt != nil {
+		return *post.UpdatedAt
+	}
+
+	return post.PublishedDate()
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description"`
+	Category    string  `xml:"category,omitempty"`
+	Guid        rssGuid `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS renders f as an RSS 2.0 document.
+func RSS(f Feed) ([]byte, error) {
+	doc := rss{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       f.Project.Name,
+			Link:        f.Link,
+			Description: fmt.Sprintf("Updates from %s", f.Project.Name),
+			AtomLink:    atomLink{Href: f.Self, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, 0, len(f.Posts)),
+		},
+	}
+
+	if !f.Updated.IsZero() {
+		doc.Channel.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, post := range f.Posts {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       post.Title,
+			Link:        postURL(f, post),
+			Description: markdown.ToHTML(post.Content),
+			Category:    post.Category,
+			Guid:        rssGuid{Value: PostID(post)},
+			PubDate:     post.PublishedDate().UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	return marshal(doc)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomEntry struct {
+	ID        string        `xml:"id"`
+	Title     string        `xml:"title"`
+	Link      atomLink      `xml:"link"`
+	Published string        `xml:"published"`
+	Updated   string        `xml:"updated"`
+	Category  *atomCategory `xml:"category"`
+	Content   atomContent   `xml:"content"`
+}
+
+// Atom renders f as an Atom 1.0 document.
+func Atom(f Feed) ([]byte, error) {
+	updated := f.Updated
+
+	if updated.IsZero() {
+		updated = f.Project.CreatedAt
+	}
+
+	doc := atomFeed{
+		ID:      projectID(f.Project),
+		Title:   f.Project.Name,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: f.Project.Name},
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate"},
+			{Href: f.Self, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]atomEntry, 0, len(f.Posts)),
+	}
+
+	for _, post := range f.Posts {
+		entry := atomEntry{
+			ID:        PostID(post),
+			Title:     post.Title,
+			Link:      atomLink{Href: postURL(f, post), Rel: "alternate"},
+			Published: post.PublishedDate().UTC().Format(time.RFC3339),
+			Updated:   postUpdated(post).UTC().Format(time.RFC3339),
+			Content:   atomContent{Type: "html", Value: markdown.ToHTML(post.Content)},
+		}
+
+		if post.Category != "" {
+			entry.Category = &atomCategory{Term: post.Category}
+		}
+
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return marshal(doc)
+}
+
+func marshal(doc any) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,73 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+)
+
+const JSONFeedContentType = "application/feed+json; charset=utf-8"
+
+// https://www.jsonfeed.org/version/1.1/
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url,omitempty"`
+	FeedURL     string           `json:"feed_url,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Authors     []jsonFeedAuthor `json:"authors,omitempty"`
+	Items       []jsonFeedItem   `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	ContentHTML   string   `json:"content_html"`
+	ContentText   string   `json:"content_text"`
+	DatePublished string   `json:"date_published"`
+	DateModified  string   `json:"date_modified,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// JSONFeed renders f as a JSON Feed 1.1 document.
+func JSONFeed(f Feed) ([]byte, error) {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Project.Name,
+		HomePageURL: f.Link,
+		FeedURL:     f.Self,
+		Description: fmt.Sprintf("Updates from %s", f.Project.Name),
+		Authors:     []jsonFeedAuthor{{Name: f.Project.Name}},
+		Items:       make([]jsonFeedItem, 0, len(f.Posts)),
+	}
+
+	for _, post := range f.Posts {
+		item := jsonFeedItem{
+			ID:            PostID(post),
+			URL:           postURL(f, post),
+			Title:         post.Title,
+			ContentHTML:   markdown.ToHTML(post.Content),
+			ContentText:   markdown.PlainText(post.Content),
+			DatePublished: post.PublishedDate().UTC().Format(time.RFC3339),
+		}
+
+		if post.UpdatedAt != nil {
+			item.DateModified = post.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+
+		if post.Category != "" {
+			item.Tags = []string{post.Category}
+		}
+
+		doc.Items = append(doc.Items, item)
+	}
+
+	return json.Marshal(doc)
+}
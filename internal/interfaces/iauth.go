@@ -8,7 +8,22 @@ import (
 )
 
 type IAuth interface {
-	Login(context.Context, request.LoginRequest) (entity.User, string, error)
+	GetUser(context.Context, uint) (entity.User, error)
+	CheckPassword(context.Context, request.LoginRequest) (entity.User, error)
+	IssueTokens(context.Context, entity.User) (entity.AuthTokens, error)
 	Register(context.Context, request.RegisterRequest) (uint, error)
-	ForgotPassword(context.Context, request.LoginRequest) (string, error)
+	ForgotPassword(context.Context, request.ForgotPasswordRequest) (entity.PasswordResetToken, error)
+	ResetPassword(context.Context, request.ResetPasswordRequest) (uint, error)
+	Refresh(context.Context, string) (entity.AuthTokens, error)
+	Logout(context.Context, string) error
+	IsTokenRevoked(context.Context, string) (bool, error)
+	RequestEmailVerification(context.Context, request.ResendVerificationRequest) (entity.User, string, error)
+	VerifyEmail(context.Context, request.VerifyEmailRequest) (entity.User, error)
+	IssueMfaChallenge(context.Context, entity.User) (string, error)
+	ParseMfaChallenge(context.Context, string) (entity.User, error)
+	CheckTotpCode(context.Context, entity.User, string) error
+	EnrollTotp(context.Context, uint) (entity.TotpEnrollment, error)
+	ConfirmTotp(context.Context, uint, request.TotpCodeRequest) ([]string, error)
+	DisableTotp(context.Context, uint, request.TotpCodeRequest) error
+	LinkIdentity(context.Context, entity.UserIdentity) (entity.User, error)
 }
\ No newline at end of file
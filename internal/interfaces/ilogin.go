@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+)
+
+// ILogin is implemented by the service layer only, it throttles the password and 2FA
+// code checks of IAuth with the login limiter.
+type ILogin interface {
+	Login(context.Context, request.LoginRequest) (entity.User, entity.AuthTokens, error)
+	VerifyMfa(context.Context, request.VerifyMfaRequest) (entity.User, entity.AuthTokens, error)
+}
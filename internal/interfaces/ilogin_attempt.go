@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+)
+
+type ILoginAttempt interface {
+	GetLoginAttempt(context.Context, string) (entity.LoginAttempt, error)
+	RecordLoginFailure(context.Context, string, time.Time, time.Duration) (entity.LoginAttempt, error)
+	LockLogin(context.Context, string, time.Time) error
+	ResetLoginAttempts(context.Context, string) error
+	PruneLoginAttempts(context.Context, time.Time) (int64, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+)
+
+// IOidc is implemented by the service layer only, the provider authenticates the user
+// and IAuth.LinkIdentity finds their account.
+type IOidc interface {
+	StartOidc(context.Context, string) (string, string, error)
+	FinishOidc(context.Context, string, string, request.OidcCallbackRequest) (entity.User, entity.AuthTokens, error)
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+)
+
+type IOrganization interface {
+	GetOrganizations(context.Context, uint) ([]entity.Organization, error)
+	GetOrganization(context.Context, uint) (entity.Organization, error)
+	GetPersonalOrganization(context.Context, uint) (entity.Organization, error)
+	AddOrganization(context.Context, uint, request.AddOrganizationRequest) (entity.Organization, error)
+	UpdateOrganization(context.Context, uint, request.AddOrganizationRequest) (entity.Organization, error)
+	GetOrgMembers(context.Context, uint) ([]entity.OrgMember, error)
+	AddOrgMember(context.Context, uint, request.AddOrgMemberRequest) (entity.OrgMember, error)
+	UpdateOrgMember(context.Context, uint, uint, request.UpdateOrgMemberRequest) (entity.OrgMember, error)
+	DeleteOrgMember(context.Context, uint, uint) error
+	GetOrgMemberRole(context.Context, uint, uint) (string, error)
+}
@@ -11,4 +11,10 @@ import (
 type IPost interface {
 	CreatePost(context.Context, request.AddPostRequest) (entity.Post, error)
 	GetPost(context.Context, request.GetPostRequest) (utils.PaginateResult[entity.Post], error)
+	GetPostById(context.Context, uint) (entity.Post, error)
+	UpdatePost(context.Context, uint, request.UpdatePostRequest) (entity.Post, error)
+	PatchPost(context.Context, uint, request.PatchPostRequest) (entity.Post, error)
+	DeletePost(context.Context, uint) error
+	PublishPost(context.Context, uint) (entity.Post, error)
+	UnpublishPost(context.Context, uint) (entity.Post, error)
 }
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+)
+
+type IPostScheduler interface {
+	// PublishDuePosts queues the deliveries fn returns for each post and the webhooks
+	// of its project subscribed to post.published, in the transaction of the publish.
+	PublishDuePosts(context.Context, int, func(entity.Post, []entity.ProjectWebhook) []entity.WebhookDelivery) ([]entity.Post, error)
+}
@@ -12,6 +12,9 @@ type IProject interface {
 	CheckSlug(context.Context, request.CheckSlugRequest) (bool, error)
 	AddProject(context.Context, uint, request.AddProjectRequest) (entity.Project, error)
 	GetProject(context.Context, uint, request.PaginationRequest) (utils.PaginateResult[entity.Project], error)
+	GetProjectById(context.Context, uint) (entity.Project, error)
 	DeleteProject(context.Context, uint) error
 	UpdateProject(context.Context, uint, request.AddProjectRequest) (entity.Project, error)
+	GetWebhookSecret(context.Context, uint) (string, error)
+	RotateWebhookSecret(context.Context, uint) (string, error)
 }
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+)
+
+type IProjectApiKey interface {
+	GetApiKeys(context.Context, uint) ([]entity.ProjectApiKey, error)
+	AddApiKey(context.Context, uint, uint, request.AddProjectApiKeyRequest) (entity.ProjectApiKey, error)
+	RevokeApiKey(context.Context, uint, uint) error
+	AuthenticateApiKey(context.Context, string) (entity.ProjectApiKey, error)
+}
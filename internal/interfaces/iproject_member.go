@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+)
+
+type IProjectMember interface {
+	GetMembers(context.Context, uint) ([]entity.ProjectMember, error)
+	AddMember(context.Context, uint, request.AddProjectMemberRequest) (entity.ProjectMember, error)
+	UpdateMember(context.Context, uint, uint, request.UpdateProjectMemberRequest) (entity.ProjectMember, error)
+	DeleteMember(context.Context, uint, uint) error
+	GetMemberRole(context.Context, uint, uint) (string, error)
+	AcceptInvites(context.Context, uint, string) (int64, error)
+}
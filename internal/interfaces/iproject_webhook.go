@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+)
+
+type IProjectWebhook interface {
+	GetWebhooks(context.Context, uint) ([]entity.ProjectWebhook, error)
+	GetWebhook(context.Context, uint, uint) (entity.ProjectWebhook, error)
+	AddWebhook(context.Context, uint, request.AddProjectWebhookRequest) (entity.ProjectWebhook, error)
+	UpdateWebhook(context.Context, uint, uint, request.AddProjectWebhookRequest) (entity.ProjectWebhook, error)
+	DeleteWebhook(context.Context, uint, uint) error
+	GetSubscribedWebhooks(context.Context, uint, string) ([]entity.ProjectWebhook, error)
+}
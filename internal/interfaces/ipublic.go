@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/utils"
+)
+
+type IPublic interface {
+	GetPublicProject(context.Context, string) (entity.Project, error)
+	GetPublicPosts(context.Context, uint, request.GetPublicPostRequest) (utils.PaginateResult[entity.Post], error)
+	CountPublicPostsSince(context.Context, uint, time.Time) (int64, error)
+	GetPublicPost(context.Context, uint, uint) (entity.Post, error)
+	GetPublicCategories(context.Context, uint) ([]string, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+)
+
+type ISigningKey interface {
+	GetSigningKeys(context.Context) ([]entity.SigningKey, error)
+	AddSigningKey(context.Context, entity.SigningKey) error
+	DeleteSigningKeys(context.Context, []string) error
+	// LockSigningKeys runs fn with the keys locked against the other replicas, fn must
+	// use the ISigningKey it gets.
+	LockSigningKeys(context.Context, func(ISigningKey) error) error
+}
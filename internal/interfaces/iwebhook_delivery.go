@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/utils"
+)
+
+type IWebhookDelivery interface {
+	GetDeliveries(context.Context, uint, request.GetWebhookDeliveryRequest) (utils.PaginateResult[entity.WebhookDelivery], error)
+	GetDelivery(context.Context, uint, uint) (entity.WebhookDelivery, error)
+	RedeliverDelivery(context.Context, uint, uint) (entity.WebhookDelivery, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+)
+
+type IWebhookQueue interface {
+	EnqueueDelivery(context.Context, entity.WebhookDelivery) (entity.WebhookDelivery, error)
+	ClaimDueDeliveries(context.Context, int, time.Duration) ([]entity.WebhookDelivery, error)
+	SaveDeliveryResult(context.Context, entity.WebhookDelivery, entity.WebhookDeliveryAttempt) error
+}
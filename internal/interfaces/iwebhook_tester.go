@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+)
+
+// IWebhookTester is implemented by the service layer only, it works on sample
+// posts and never touches the delivery queue.
+type IWebhookTester interface {
+	PreviewTemplate(context.Context, uint, request.PreviewWebhookTemplateRequest) (string, error)
+	TestWebhook(context.Context, uint, request.TestWebhookRequest) (response.WebhookTestResult, error)
+}
@@ -0,0 +1,108 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// JWK is the public key of a signing key, RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys that verify tokens, and the next key before it signs.
+func (m *KeyManager) JWKS() JWKS {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	jwks := JWKS{Keys: []JWK{}}
+
+	for i, k := range m.keys {
+		if i+1 < len(m.keys) && m.keys[i+1].activatesAt.Add(m.cfg.VerifyFor).Before(now) {
+			continue
+		}
+
+		jwk, err := publicJWK(k.private.Public())
+
+		if err != nil {
+			continue
+		}
+
+		jwk.Kid = k.kid
+		jwk.Use = "sig"
+		jwk.Alg = k.algorithm
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+func publicJWK(public crypto.PublicKey) (JWK, error) {
+	switch public := public.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(public),
+		}, nil
+	default:
+		return JWK{}, fmt.Errorf("jwtkeys: unsupported key type %T", public)
+	}
+}
+
+// thumbprint is the RFC 7638 thumbprint of public, used as its kid.
+func thumbprint(public crypto.PublicKey) (string, error) {
+	jwk, err := publicJWK(public)
+
+	if err != nil {
+		return "", err
+	}
+
+	// the required members in lexicographic order, json.Marshal sorts map keys
+	members := map[string]string{"kty": jwk.Kty}
+
+	if jwk.Kty == "RSA" {
+		members["n"] = jwk.N
+		members["e"] = jwk.E
+	} else {
+		members["crv"] = jwk.Crv
+		members["x"] = jwk.X
+	}
+
+	data, err := json.Marshal(members)
+
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,512 @@
+// Package jwtkeys signs and verifies the JWTs of LogStream with asymmetric keys: RS256
+// or EdDSA, with a kid header naming the key. Keys rotate on a schedule: a new key is
+// published in the JWKS before it signs, and a replaced key keeps verifying until the
+// tokens it signed expired. Keys are kept, encrypted, by a backend shared by replicas.
+//
+// Only access tokens are signed with those keys, with a "typ: at+jwt" header and the
+// iss and aud of Config. Purpose tokens, e.g. email verification, are signed with an
+// HMAC key that is never published, so other services can't take them for credentials.
+package jwtkeys
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/interfaces"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	// typ header of access tokens, RFC 9068
+	AccessTokenType = "at+jwt"
+
+	rsaKeyBits = 2048
+	// unknown kids reload the keys at most this often, another replica may have added one
+	reloadOnMissInterval = 10 * time.Second
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+type Config struct {
+	// algorithm of new keys, older keys keep theirs
+	Algorithm string
+	// a new key signs every RotateEvery
+	RotateEvery time.Duration
+	// new keys are published in the JWKS this long before they sign, for verifiers
+	// caching it
+	PublishAhead time.Duration
+	// a replaced key keeps verifying this long, more than the longest token lifetime
+	VerifyFor time.Duration
+	// how often keys are reloaded from the backend and rotated
+	CheckInterval time.Duration
+	// encrypts the private keys in the backend, and derives the key of purpose tokens
+	Secret []byte
+	// iss and aud claims of access tokens, verifiers must check both
+	Issuer   string
+	Audience string
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Issuer:        "logstream",
+		Audience:      "logstream-api",
+		Algorithm:     AlgorithmRS256,
+		RotateEvery:   30 * 24 * time.Hour,
+		PublishAhead:  time.Hour,
+		VerifyFor:     72 * time.Hour,
+		CheckInterval: time.Minute,
+	}
+}
+
+type key struct {
+	kid         string
+	algorithm   string
+	activatesAt time.Time
+	private     crypto.Signer
+}
+
+func (k key) method() jwt.SigningMethod {
+	if k.algorithm == AlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+
+	return jwt.SigningMethodRS256
+}
+
+type KeyManager struct {
+	backend interfaces.ISigningKey
+	cfg     Config
+	logger  *zap.Logger
+
+	mu sync.RWMutex
+	// sorted by activatesAt, the last active one signs
+	keys       []key
+	reloadedAt time.Time
+}
+
+func New(backend interfaces.ISigningKey, cfg Config, logger *zap.Logger) *KeyManager {
+	return &KeyManager{
+		backend: backend,
+		cfg:     cfg,
+		logger:  logger,
+	}
+}
+
+// Init loads the keys and creates the first one, it must succeed before tokens are
+// signed.
+func (m *KeyManager) Init(ctx context.Context) error {
+	if len(m.cfg.Secret) == 0 {
+		return errors.New("jwtkeys: a secret is required to encrypt the keys")
+	}
+
+	if m.cfg.Algorithm != AlgorithmRS256 && m.cfg.Algorithm != AlgorithmEdDSA {
+		return fmt.Errorf("jwtkeys: unsupported algorithm %q", m.cfg.Algorithm)
+	}
+
+	// a key due before it is even published would be replaced on every check
+	if m.cfg.PublishAhead < 0 || m.cfg.RotateEvery <= m.cfg.PublishAhead {
+		return fmt.Errorf("jwtkeys: the rotation period %v must be longer than the publication %v", m.cfg.RotateEvery, m.cfg.PublishAhead)
+	}
+
+	if m.cfg.VerifyFor <= 0 || m.cfg.CheckInterval <= 0 {
+		return errors.New("jwtkeys: the verification period and check interval must be positive")
+	}
+
+	if m.cfg.Issuer == "" || m.cfg.Audience == "" {
+		return errors.New("jwtkeys: an issuer and an audience are required")
+	}
+
+	return m.check(ctx)
+}
+
+// Run reloads and rotates the keys every CheckInterval until ctx is canceled.
+func (m *KeyManager) Run(ctx context.Context) error {
+	m.logger.Info("starting signing key rotation", zap.String("Algorithm", m.cfg.Algorithm), zap.Duration("RotateEvery", m.cfg.RotateEvery))
+
+	ticker := time.NewTicker(m.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.check(ctx); err != nil {
+				m.logger.Error("⚠️ Failed to rotate signing keys", zap.Error(err))
+			}
+		}
+	}
+}
+
+// check reloads the keys, adds the next key when the last one is due to be replaced
+// and drops the keys that no longer verify.
+func (m *KeyManager) check(ctx context.Context) error {
+	if err := m.reload(ctx); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	m.mu.RLock()
+	var last *time.Time
+	if len(m.keys) > 0 {
+		last = &m.keys[len(m.keys)-1].activatesAt
+	}
+	_, due := m.nextKey(last, now)
+	m.mu.RUnlock()
+
+	if due {
+		var kid string
+		var activatesAt time.Time
+
+		err := m.backend.LockSigningKeys(ctx, func(backend interfaces.ISigningKey) error {
+			// another replica may have added the key while this one waited for the lock
+			rows, err := backend.GetSigningKeys(ctx)
+
+			if err != nil {
+				return err
+			}
+
+			var newest *time.Time
+			for i := range rows {
+				if newest == nil || rows[i].ActivatesAt.After(*newest) {
+					newest = &rows[i].ActivatesAt
+				}
+			}
+
+			activatesAt, due = m.nextKey(newest, now)
+
+			if !due {
+				return nil
+			}
+
+			kid, err = m.addKey(ctx, backend, activatesAt)
+			return err
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if kid != "" {
+			m.logger.Info("✅ Signing key added", zap.String("Kid", kid), zap.String("Algorithm", m.cfg.Algorithm), zap.Time("ActivatesAt", activatesAt))
+		}
+
+		if err := m.reload(ctx); err != nil {
+			return err
+		}
+	}
+
+	m.mu.RLock()
+	expired := m.expired(now)
+	m.mu.RUnlock()
+
+	if len(expired) == 0 {
+		return nil
+	}
+
+	if err := m.backend.DeleteSigningKeys(ctx, expired); err != nil {
+		return err
+	}
+
+	m.logger.Info("✅ Expired signing keys dropped", zap.Strings("Kids", expired))
+
+	return m.reload(ctx)
+}
+
+// nextKey reports whether the key activating at last, nil without keys, is due to be
+// replaced at now, and when its replacement activates.
+func (m *KeyManager) nextKey(last *time.Time, now time.Time) (time.Time, bool) {
+	// the first key signs right away
+	if last == nil {
+		return now, true
+	}
+
+	if last.Add(m.cfg.RotateEvery - m.cfg.PublishAhead).After(now) {
+		return time.Time{}, false
+	}
+
+	// the next ones once published
+	activatesAt := last.Add(m.cfg.RotateEvery)
+
+	if published := now.Add(m.cfg.PublishAhead); activatesAt.Before(published) {
+		activatesAt = published
+	}
+
+	return activatesAt, true
+}
+
+// expired returns the kids of the keys replaced more than VerifyFor ago. m.mu must be
+// held.
+func (m *KeyManager) expired(now time.Time) []string {
+	var kids []string
+
+	for i := 0; i+1 < len(m.keys); i++ {
+		replacedAt := m.keys[i+1].activatesAt
+
+		if !replacedAt.After(now) && replacedAt.Add(m.cfg.VerifyFor).Before(now) {
+			kids = append(kids, m.keys[i].kid)
+		}
+	}
+
+	return kids
+}
+
+func (m *KeyManager) addKey(ctx context.Context, backend interfaces.ISigningKey, activatesAt time.Time) (string, error) {
+	var private crypto.Signer
+	var err error
+
+	switch m.cfg.Algorithm {
+	case AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := encrypt(m.cfg.Secret, der)
+
+	if err != nil {
+		return "", err
+	}
+
+	kid, err := thumbprint(private.Public())
+
+	if err != nil {
+		return "", err
+	}
+
+	err = backend.AddSigningKey(ctx, entity.SigningKey{
+		Kid:         kid,
+		Algorithm:   m.cfg.Algorithm,
+		PrivateKey:  encrypted,
+		ActivatesAt: activatesAt,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return kid, nil
+}
+
+func (m *KeyManager) reload(ctx context.Context) error {
+	rows, err := m.backend.GetSigningKeys(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	keys := make([]key, 0, len(rows))
+
+	for _, row := range rows {
+		der, err := decrypt(m.cfg.Secret, row.PrivateKey)
+
+		if err != nil {
+			return fmt.Errorf("jwtkeys: decrypt key %s: %w", row.Kid, err)
+		}
+
+		parsed, err := x509.ParsePKCS8PrivateKey(der)
+
+		if err != nil {
+			return fmt.Errorf("jwtkeys: parse key %s: %w", row.Kid, err)
+		}
+
+		private, ok := parsed.(crypto.Signer)
+
+		if !ok {
+			return fmt.Errorf("jwtkeys: key %s can't sign", row.Kid)
+		}
+
+		keys = append(keys, key{
+			kid:         row.Kid,
+			algorithm:   row.Algorithm,
+			activatesAt: row.ActivatesAt,
+			private:     private,
+		})
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].activatesAt.Before(keys[j].activatesAt)
+	})
+
+	m.mu.Lock()
+	m.keys = keys
+	m.reloadedAt = time.Now()
+	m.mu.Unlock()
+
+	return nil
+}
+
+// Sign signs the claims of an access token with the current key, adding the issuer and
+// audience.
+func (m *KeyManager) Sign(claims jwt.MapClaims) (string, error) {
+	claims["iss"] = m.cfg.Issuer
+	claims["aud"] = m.cfg.Audience
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+
+	var signer *key
+
+	for i := range m.keys {
+		if m.keys[i].activatesAt.After(now) {
+			break
+		}
+
+		signer = &m.keys[i]
+	}
+
+	if signer == nil {
+		return "", ErrUnknownKey
+	}
+
+	token := jwt.NewWithClaims(signer.method(), claims)
+	token.Header["kid"] = signer.kid
+	token.Header["typ"] = AccessTokenType
+
+	return token.SignedString(signer.private)
+}
+
+// Parse verifies the access token tokenString with the key of its kid and returns its
+// claims.
+func (m *KeyManager) Parse(tokenString string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, m.keyfunc,
+		jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmEdDSA}),
+		jwt.WithIssuer(m.cfg.Issuer),
+		jwt.WithAudience(m.cfg.Audience))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// SignPurpose signs the claims of a token proving something about a user, e.g. that
+// they own an email, with the unpublished HMAC key. Its audience is the purpose, so
+// a token of one purpose isn't accepted for another.
+func (m *KeyManager) SignPurpose(purpose string, claims jwt.MapClaims) (string, error) {
+	claims["iss"] = m.cfg.Issuer
+	claims["aud"] = purposeAudience(purpose)
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.purposeKey())
+}
+
+// ParsePurpose verifies a token of SignPurpose for purpose and returns its claims.
+func (m *KeyManager) ParsePurpose(tokenString string, purpose string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (any, error) {
+		return m.purposeKey(), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(m.cfg.Issuer),
+		jwt.WithAudience(purposeAudience(purpose)),
+		jwt.WithExpirationRequired())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func purposeAudience(purpose string) string {
+	return "purpose:" + purpose
+}
+
+// purposeKey is derived from Secret, tokens signed with Secret itself, e.g. the HS256
+// access tokens of before the keys existed, don't verify.
+func (m *KeyManager) purposeKey() []byte {
+	mac := hmac.New(sha256.New, m.cfg.Secret)
+	mac.Write([]byte("jwtkeys purpose tokens"))
+
+	return mac.Sum(nil)
+}
+
+func (m *KeyManager) keyfunc(token *jwt.Token) (any, error) {
+	if typ, _ := token.Header["typ"].(string); typ != AccessTokenType {
+		return nil, fmt.Errorf("jwtkeys: not an access token, typ %q", typ)
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	if k, ok := m.verificationKey(kid, token.Method.Alg()); ok {
+		return k, nil
+	}
+
+	m.mu.RLock()
+	stale := time.Since(m.reloadedAt) > reloadOnMissInterval
+	m.mu.RUnlock()
+
+	if !stale {
+		return nil, ErrUnknownKey
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := m.reload(ctx); err != nil {
+		m.logger.Error("⚠️ Failed to reload signing keys", zap.Error(err))
+		return nil, ErrUnknownKey
+	}
+
+	if k, ok := m.verificationKey(kid, token.Method.Alg()); ok {
+		return k, nil
+	}
+
+	return nil, ErrUnknownKey
+}
+
+// verificationKey returns the public key of kid unless it expired or has another
+// algorithm.
+func (m *KeyManager) verificationKey(kid string, algorithm string) (crypto.PublicKey, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+
+	for i, k := range m.keys {
+		if k.kid != kid || k.algorithm != algorithm {
+			continue
+		}
+
+		if i+1 < len(m.keys) && m.keys[i+1].activatesAt.Add(m.cfg.VerifyFor).Before(now) {
+			return nil, false
+		}
+
+		return k.private.Public(), true
+	}
+
+	return nil, false
+}
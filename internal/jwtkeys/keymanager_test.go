@@ -0,0 +1,548 @@
+package jwtkeys
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/interfaces"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// memoryBackend keeps the keys of replicas sharing it, LockSigningKeys stands in for the
+// advisory lock.
+type memoryBackend struct {
+	lock sync.Mutex
+
+	mu   sync.Mutex
+	keys []entity.SigningKey
+	// gets a value on every GetSigningKeys when set
+	reads chan struct{}
+}
+
+func (b *memoryBackend) GetSigningKeys(context.Context) ([]entity.SigningKey, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.reads != nil {
+		b.reads <- struct{}{}
+	}
+
+	keys := slices.Clone(b.keys)
+	slices.SortFunc(keys, func(a, b entity.SigningKey) int {
+		return a.ActivatesAt.Compare(b.ActivatesAt)
+	})
+
+	return keys, nil
+}
+
+func (b *memoryBackend) AddSigningKey(_ context.Context, key entity.SigningKey) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.keys = append(b.keys, key)
+
+	return nil
+}
+
+func (b *memoryBackend) DeleteSigningKeys(_ context.Context, kids []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.keys = slices.DeleteFunc(b.keys, func(k entity.SigningKey) bool {
+		return slices.Contains(kids, k.Kid)
+	})
+
+	return nil
+}
+
+func (b *memoryBackend) LockSigningKeys(_ context.Context, fn func(interfaces.ISigningKey) error) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return fn(b)
+}
+
+// activate moves the key kid to activate at activatesAt, the tests can't wait for a
+// rotation.
+func (b *memoryBackend) activate(kid string, activatesAt time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := range b.keys {
+		if b.keys[i].Kid == kid {
+			b.keys[i].ActivatesAt = activatesAt
+		}
+	}
+}
+
+func testConfig(algorithm string) Config {
+	cfg := DefaultConfig()
+	cfg.Algorithm = algorithm
+	cfg.Secret = []byte("test-secret")
+
+	return cfg
+}
+
+func newTestManager(t *testing.T, backend *memoryBackend, cfg Config) *KeyManager {
+	t.Helper()
+
+	m := New(backend, cfg, zap.NewNop())
+
+	if err := m.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestInitValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"no secret", func(c *Config) { c.Secret = nil }},
+		{"unknown algorithm", func(c *Config) { c.Algorithm = "HS256" }},
+		{"no rotation", func(c *Config) { c.RotateEvery = 0 }},
+		{"rotation shorter than publication", func(c *Config) { c.RotateEvery = c.PublishAhead / 2 }},
+		{"rotation equal to publication", func(c *Config) { c.RotateEvery = c.PublishAhead }},
+		{"negative publication", func(c *Config) { c.PublishAhead = -time.Hour }},
+		{"no verification", func(c *Config) { c.VerifyFor = 0 }},
+		{"no check interval", func(c *Config) { c.CheckInterval = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(AlgorithmEdDSA)
+			tt.modify(&cfg)
+			backend := &memoryBackend{}
+
+			if err := New(backend, cfg, zap.NewNop()).Init(context.Background()); err == nil {
+				t.Fatal("invalid config was accepted")
+			}
+
+			if len(backend.keys) != 0 {
+				t.Fatalf("invalid config added %d keys", len(backend.keys))
+			}
+		})
+	}
+}
+
+func TestSignAndParse(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			m := newTestManager(t, &memoryBackend{}, testConfig(algorithm))
+
+			token, err := m.Sign(jwt.MapClaims{"sub": "1", "exp": time.Now().Add(time.Minute).Unix()})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			claims, err := m.Parse(token)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if claims["sub"] != "1" {
+				t.Fatalf("got claims %v", claims)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if parsed.Method.Alg() != algorithm || parsed.Header["kid"] != m.JWKS().Keys[0].Kid {
+				t.Fatalf("got alg %v and kid %v, want %s and the kid of the JWKS", parsed.Method.Alg(), parsed.Header["kid"], algorithm)
+			}
+
+			if parsed.Header["typ"] != AccessTokenType || claims["iss"] != "logstream" || claims["aud"] != "logstream-api" {
+				t.Fatalf("got typ %v, iss %v and aud %v", parsed.Header["typ"], claims["iss"], claims["aud"])
+			}
+		})
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	backend := &memoryBackend{}
+	m := newTestManager(t, backend, testConfig(AlgorithmEdDSA))
+	other := newTestManager(t, &memoryBackend{}, testConfig(AlgorithmEdDSA))
+
+	// the keys of m for another audience
+	otherAudienceCfg := testConfig(AlgorithmEdDSA)
+	otherAudienceCfg.Audience = "other-api"
+	otherAudience := newTestManager(t, backend, otherAudienceCfg)
+
+	valid, err := m.Sign(jwt.MapClaims{"sub": "1"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromOther, err := other.Sign(jwt.MapClaims{"sub": "1"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signWith := func(signer *KeyManager, header map[string]any) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"sub": "1", "iss": "logstream", "aud": "logstream-api"})
+
+		for k, v := range header {
+			token.Header[k] = v
+		}
+
+		signer.mu.RLock()
+		defer signer.mu.RUnlock()
+
+		signed, err := token.SignedString(signer.keys[0].private)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return signed
+	}
+
+	kid := m.JWKS().Keys[0].Kid
+
+	// a key of another manager under the kid of m
+	forgedToken := signWith(other, map[string]any{"kid": kid, "typ": AccessTokenType})
+	noTyp := signWith(m, map[string]any{"kid": kid})
+
+	fromOtherAudience, err := otherAudience.Sign(jwt.MapClaims{"sub": "1"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	purpose, err := m.SignPurpose("email_verification", jwt.MapClaims{"sub": "1", "exp": time.Now().Add(time.Minute).Unix()})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hs256, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "1"}).SignedString([]byte("test-secret"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expired, err := m.Sign(jwt.MapClaims{"sub": "1", "exp": time.Now().Add(-time.Minute).Unix()})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(valid, ".")
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"2"}`)) + "." + parts[2]
+
+	tests := map[string]string{
+		"unknown kid":    fromOther,
+		"forged":         forgedToken,
+		"no typ":         noTyp,
+		"other audience": fromOtherAudience,
+		"purpose token":  purpose,
+		"HS256":          hs256,
+		"expired":        expired,
+		"tampered":       tampered,
+		"not a token":    "token",
+		"empty":          "",
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := m.Parse(token); err == nil {
+				t.Fatal("token was accepted")
+			}
+		})
+	}
+}
+
+func TestPurposeTokens(t *testing.T) {
+	m := newTestManager(t, &memoryBackend{}, testConfig(AlgorithmEdDSA))
+
+	token, err := m.SignPurpose("email_verification", jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Minute).Unix()})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := m.ParsePurpose(token, "email_verification")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims["user_id"] != float64(1) {
+		t.Fatalf("got claims %v", claims)
+	}
+
+	access, err := m.Sign(jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Minute).Unix()})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// signed with SECRET_KEY itself, like the HS256 tokens before the keys existed
+	rawSecret, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": 1,
+		"iss":     "logstream",
+		"aud":     "purpose:email_verification",
+		"exp":     time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte("test-secret"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	noExpiry, err := m.SignPurpose("email_verification", jwt.MapClaims{"user_id": 1})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		purpose string
+	}{
+		{"other purpose", token, "mfa_challenge"},
+		{"access token", access, "email_verification"},
+		{"raw secret", rawSecret, "email_verification"},
+		{"no expiry", noExpiry, "email_verification"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := m.ParsePurpose(tt.token, tt.purpose); err == nil {
+				t.Fatal("token was accepted")
+			}
+		})
+	}
+
+	// the key isn't published, a verifier of the JWKS can't accept purpose tokens
+	for _, jwk := range m.JWKS().Keys {
+		if jwk.Kty == "oct" {
+			t.Fatalf("JWKS publishes a symmetric key %s", jwk.Kid)
+		}
+	}
+}
+
+func TestRotation(t *testing.T) {
+	backend := &memoryBackend{}
+	cfg := testConfig(AlgorithmEdDSA)
+	m := newTestManager(t, backend, cfg)
+	ctx := context.Background()
+
+	first := backend.keys[0].Kid
+
+	// not due yet
+	if err := m.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(backend.keys) != 1 {
+		t.Fatalf("got %d keys before the rotation is due, want 1", len(backend.keys))
+	}
+
+	oldToken, err := m.Sign(jwt.MapClaims{"sub": "1"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// due: the next key is published ahead of signing
+	backend.activate(first, time.Now().Add(-cfg.RotateEvery+cfg.PublishAhead/2))
+
+	if err := m.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(backend.keys) != 2 {
+		t.Fatalf("got %d keys once the rotation is due, want 2", len(backend.keys))
+	}
+
+	second := backend.keys[1].Kid
+
+	if !backend.keys[1].ActivatesAt.After(time.Now()) {
+		t.Fatal("the next key signs before it is published")
+	}
+
+	if kids := jwksKids(m); !slices.Equal(kids, []string{first, second}) {
+		t.Fatalf("JWKS has %v, want the current and the next key", kids)
+	}
+
+	if kid := signingKid(t, m); kid != first {
+		t.Fatalf("signed with %s before the next key activates, want %s", kid, first)
+	}
+
+	// the next key signs, the replaced one keeps verifying
+	backend.activate(second, time.Now().Add(-time.Minute))
+
+	if err := m.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if kid := signingKid(t, m); kid != second {
+		t.Fatalf("signed with %s once the next key is active, want %s", kid, second)
+	}
+
+	if _, err := m.Parse(oldToken); err != nil {
+		t.Fatalf("token of the replaced key got %v", err)
+	}
+
+	// the replaced key is dropped after VerifyFor
+	backend.activate(second, time.Now().Add(-cfg.VerifyFor-time.Minute))
+
+	if err := m.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if kids := jwksKids(m); !slices.Equal(kids, []string{second}) {
+		t.Fatalf("JWKS has %v after VerifyFor, want %v", kids, []string{second})
+	}
+
+	if slices.ContainsFunc(backend.keys, func(k entity.SigningKey) bool { return k.Kid == first }) {
+		t.Fatal("the replaced key wasn't deleted")
+	}
+
+	if _, err := m.Parse(oldToken); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("token of the dropped key got %v, want %v", err, ErrUnknownKey)
+	}
+}
+
+// TestReplicasAddOneFirstKey starts replicas together on an empty backend, the lock
+// makes them share the first key.
+func TestReplicasAddOneFirstKey(t *testing.T) {
+	const replicas = 5
+
+	// every replica reads the empty backend before any of them gets the lock
+	backend := &memoryBackend{reads: make(chan struct{}, 10*replicas)}
+	backend.lock.Lock()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, replicas)
+
+	for range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- New(backend, testConfig(AlgorithmEdDSA), zap.NewNop()).Init(context.Background())
+		}()
+	}
+
+	for range replicas {
+		<-backend.reads
+	}
+
+	backend.lock.Unlock()
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(backend.keys) != 1 {
+		t.Fatalf("replicas added %d first keys, want 1", len(backend.keys))
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	m := newTestManager(t, &memoryBackend{}, testConfig(AlgorithmRS256))
+
+	jwks := m.JWKS()
+
+	if len(jwks.Keys) != 1 {
+		t.Fatalf("got %d keys, want 1", len(jwks.Keys))
+	}
+
+	jwk := jwks.Keys[0]
+
+	if jwk.Kty != "RSA" || jwk.Use != "sig" || jwk.Alg != AlgorithmRS256 {
+		t.Fatalf("got %+v", jwk)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	public := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+	// a verifier only knowing the JWKS accepts the tokens
+	token, err := m.Sign(jwt.MapClaims{"sub": "1"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = jwt.Parse(token, func(*jwt.Token) (any, error) { return public, nil }, jwt.WithValidMethods([]string{AlgorithmRS256}))
+
+	if err != nil {
+		t.Fatalf("token doesn't verify with the JWKS key: %v", err)
+	}
+
+	kid, err := thumbprint(public)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if jwk.Kid != kid {
+		t.Fatalf("kid %s isn't the thumbprint %s", jwk.Kid, kid)
+	}
+}
+
+func jwksKids(m *KeyManager) []string {
+	var kids []string
+
+	for _, k := range m.JWKS().Keys {
+		kids = append(kids, k.Kid)
+	}
+
+	return kids
+}
+
+func signingKid(t *testing.T, m *KeyManager) string {
+	t.Helper()
+
+	token, err := m.Sign(jwt.MapClaims{"sub": "1"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kid, _ := parsed.Header["kid"].(string)
+
+	return kid
+}
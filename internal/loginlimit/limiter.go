@@ -0,0 +1,240 @@
+// Package loginlimit throttles failed logins per account and per client IP: after a few
+// free attempts each failure doubles the wait before the next attempt, and too many
+// failures lock the key for a while. Attempts are counted by a backend, Memory for a
+// single replica or store.LoginAttemptStore to share them between replicas.
+package loginlimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/internal/interfaces"
+)
+
+// Policy is the throttling of one kind of key.
+type Policy struct {
+	// failures before delays start
+	FreeAttempts int
+	// wait after the first failure past FreeAttempts, doubled by each further failure
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// failures that lock the key for Lockout
+	MaxAttempts int
+	Lockout     time.Duration
+	// failures older than Window are forgotten
+	Window time.Duration
+}
+
+type Config struct {
+	// policy of the account keys, EmailKey and TotpKey
+	Email Policy
+	// higher than Email, many users can share an IP behind a NAT
+	IP Policy
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Email: Policy{
+			FreeAttempts: 3,
+			BaseDelay:    time.Second,
+			MaxDelay:     30 * time.Second,
+			MaxAttempts:  10,
+			Lockout:      15 * time.Minute,
+			Window:       15 * time.Minute,
+		},
+		IP: Policy{
+			FreeAttempts: 10,
+			BaseDelay:    time.Second,
+			MaxDelay:     30 * time.Second,
+			MaxAttempts:  50,
+			Lockout:      15 * time.Minute,
+			Window:       15 * time.Minute,
+		},
+	}
+}
+
+// ThrottledError is returned by Check while a key has to wait.
+type ThrottledError struct {
+	RetryAfter time.Duration
+	// the key is locked out, not only delayed
+	Locked bool
+}
+
+func (e *ThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed logins, locked for %s", e.RetryAfter.Round(time.Second))
+	}
+
+	return fmt.Sprintf("too many failed logins, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// Lockout is reported by Fail when a failure locks a key.
+type Lockout struct {
+	Key      string
+	Failures int
+	Until    time.Time
+}
+
+// pruneInterval is how often Fail forgets old attempts of the backend
+const pruneInterval = 10 * time.Minute
+
+type Limiter struct {
+	backend interfaces.ILoginAttempt
+	cfg     Config
+	now     func() time.Time
+	// unix nanos of the last prune
+	lastPrune atomic.Int64
+}
+
+func New(backend interfaces.ILoginAttempt, cfg Config) *Limiter {
+	l := &Limiter{
+		backend: backend,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+	l.lastPrune.Store(l.now().UnixNano())
+
+	return l
+}
+
+type key struct {
+	name   string
+	policy Policy
+}
+
+// EmailKey is the account key counting the failed passwords of email.
+func EmailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// TotpKey is the account key counting the failed 2FA codes of a user. It is apart
+// from EmailKey, a correct password mustn't reset the failed codes.
+func TotpKey(userId uint) string {
+	return fmt.Sprintf("totp:%d", userId)
+}
+
+func (l *Limiter) keys(ip string, account string) []key {
+	keys := []key{{account, l.cfg.Email}}
+
+	if ip != "" {
+		keys = append(keys, key{"ip:" + ip, l.cfg.IP})
+	}
+
+	return keys
+}
+
+// delay is the wait after failures, 0 while they are free.
+func (p Policy) delay(failures int) time.Duration {
+	extra := failures - p.FreeAttempts
+
+	if extra <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+
+	for i := 1; i < extra && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxDelay)
+}
+
+// Check returns a *ThrottledError when the account key or the ip has to wait before the
+// next attempt. Call it before comparing passwords.
+//
+// Check only reads the failures, Fail counts them after the password was compared. A
+// burst of parallel attempts can all pass Check before the first Fail, so at most one
+// guess per in-flight request gets past the delays; the failures are still counted
+// and MaxAttempts locks the key once they are.
+func (l *Limiter) Check(ctx context.Context, ip string, account string) error {
+	now := l.now()
+
+	var throttled *ThrottledError
+
+	for _, k := range l.keys(ip, account) {
+		attempt, err := l.backend.GetLoginAttempt(ctx, k.name)
+
+		if err != nil {
+			return err
+		}
+
+		var wait time.Duration
+		locked := false
+
+		if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+			wait = attempt.LockedUntil.Sub(now)
+			locked = true
+		} else if now.Sub(attempt.LastFailureAt) < k.policy.Window {
+			wait = attempt.LastFailureAt.Add(k.policy.delay(attempt.Failures)).Sub(now)
+		}
+
+		if wait > 0 && (throttled == nil || wait > throttled.RetryAfter) {
+			throttled = &ThrottledError{RetryAfter: wait, Locked: locked}
+		}
+	}
+
+	if throttled != nil {
+		return throttled
+	}
+
+	return nil
+}
+
+// Fail counts a failed login of the account key from ip, it returns the keys the
+// failure locked.
+func (l *Limiter) Fail(ctx context.Context, ip string, account string) ([]Lockout, error) {
+	now := l.now()
+
+	var lockouts []Lockout
+	var errs []error
+
+	for _, k := range l.keys(ip, account) {
+		attempt, err := l.backend.RecordLoginFailure(ctx, k.name, now, k.policy.Window)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if attempt.Failures < k.policy.MaxAttempts {
+			continue
+		}
+
+		until := now.Add(k.policy.Lockout)
+
+		if err := l.backend.LockLogin(ctx, k.name, until); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		lockouts = append(lockouts, Lockout{Key: k.name, Failures: attempt.Failures, Until: until})
+	}
+
+	l.prune(ctx, now)
+
+	return lockouts, errors.Join(errs...)
+}
+
+// Succeed forgets the failures of the account key. Those of the ip are kept, a valid
+// account of an attacker mustn't reset the counter of their IP.
+func (l *Limiter) Succeed(ctx context.Context, account string) error {
+	return l.backend.ResetLoginAttempts(ctx, account)
+}
+
+func (l *Limiter) prune(ctx context.Context, now time.Time) {
+	last := l.lastPrune.Load()
+
+	if now.Sub(time.Unix(0, last)) < pruneInterval || !l.lastPrune.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+
+	window := max(l.cfg.Email.Window, l.cfg.IP.Window, l.cfg.Email.Lockout, l.cfg.IP.Lockout)
+
+	// pruning is best effort, the next one retries
+	l.backend.PruneLoginAttempts(ctx, now.Add(-window))
+}
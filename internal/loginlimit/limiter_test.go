@@ -0,0 +1,221 @@
+package loginlimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var testConfig = Config{
+	Email: Policy{
+		FreeAttempts: 2,
+		BaseDelay:    time.Second,
+		MaxDelay:     4 * time.Second,
+		MaxAttempts:  5,
+		Lockout:      time.Hour,
+		Window:       10 * time.Minute,
+	},
+	IP: Policy{
+		FreeAttempts: 10,
+		BaseDelay:    time.Second,
+		MaxDelay:     4 * time.Second,
+		MaxAttempts:  50,
+		Lockout:      time.Hour,
+		Window:       10 * time.Minute,
+	},
+}
+
+// clock is a manual Limiter.now.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestLimiter() (*Limiter, *Memory, *clock) {
+	backend := NewMemory()
+	c := &clock{now: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)}
+
+	l := New(backend, testConfig)
+	l.now = c.Now
+	l.lastPrune.Store(c.now.UnixNano())
+
+	return l, backend, c
+}
+
+func TestPolicyDelay(t *testing.T) {
+	p := Policy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{3, 0},
+		{4, time.Second},
+		{5, 2 * time.Second},
+		{6, 4 * time.Second},
+		{8, 16 * time.Second},
+		{9, 30 * time.Second},
+		{100, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := p.delay(tt.failures); got != tt.want {
+			t.Errorf("delay(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestCheckDelaysAfterFreeAttempts(t *testing.T) {
+	ctx := context.Background()
+	l, _, c := newTestLimiter()
+	account := EmailKey("a@example.com")
+
+	for range testConfig.Email.FreeAttempts {
+		if _, err := l.Fail(ctx, "10.0.0.1", account); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := l.Check(ctx, "10.0.0.1", account); err != nil {
+			t.Fatalf("free attempt throttled: %v", err)
+		}
+	}
+
+	if _, err := l.Fail(ctx, "10.0.0.1", account); err != nil {
+		t.Fatal(err)
+	}
+
+	var throttled *ThrottledError
+
+	if err := l.Check(ctx, "10.0.0.1", account); !errors.As(err, &throttled) || throttled.Locked || throttled.RetryAfter != time.Second {
+		t.Fatalf("got %v, want a delay of 1s", err)
+	}
+
+	c.Add(time.Second)
+
+	if err := l.Check(ctx, "10.0.0.1", account); err != nil {
+		t.Fatalf("still throttled after the delay: %v", err)
+	}
+}
+
+func TestFailLocksAtMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	l, _, c := newTestLimiter()
+	account := EmailKey("a@example.com")
+
+	for i := 1; i <= testConfig.Email.MaxAttempts; i++ {
+		lockouts, err := l.Fail(ctx, "10.0.0.1", account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i < testConfig.Email.MaxAttempts && len(lockouts) != 0 {
+			t.Fatalf("failure %d locked %v", i, lockouts)
+		}
+
+		if i == testConfig.Email.MaxAttempts && (len(lockouts) != 1 || lockouts[0].Key != account || lockouts[0].Failures != i) {
+			t.Fatalf("failure %d locked %v, want %s", i, lockouts, account)
+		}
+
+		c.Add(time.Second)
+	}
+
+	var throttled *ThrottledError
+
+	if err := l.Check(ctx, "10.0.0.1", account); !errors.As(err, &throttled) || !throttled.Locked {
+		t.Fatalf("got %v, want a lockout", err)
+	}
+
+	if want := testConfig.Email.Lockout - time.Second; throttled.RetryAfter != want {
+		t.Fatalf("retry after %s, want %s", throttled.RetryAfter, want)
+	}
+
+	// the ip isn't locked, another account from it may log in
+	if err := l.Check(ctx, "10.0.0.1", EmailKey("b@example.com")); err != nil {
+		t.Fatalf("other account throttled: %v", err)
+	}
+
+	c.Add(testConfig.Email.Lockout)
+
+	if err := l.Check(ctx, "10.0.0.1", account); err != nil {
+		t.Fatalf("still throttled after the lockout: %v", err)
+	}
+}
+
+func TestFailuresAreForgottenAfterWindow(t *testing.T) {
+	ctx := context.Background()
+	l, backend, c := newTestLimiter()
+	account := EmailKey("a@example.com")
+
+	for range testConfig.Email.MaxAttempts - 1 {
+		if _, err := l.Fail(ctx, "", account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := l.Check(ctx, "", account); err == nil {
+		t.Fatal("want a delay")
+	}
+
+	c.Add(testConfig.Email.Window)
+
+	if err := l.Check(ctx, "", account); err != nil {
+		t.Fatalf("throttled after the window: %v", err)
+	}
+
+	// counting starts over, the next failure doesn't lock
+	lockouts, err := l.Fail(ctx, "", account)
+
+	if err != nil || len(lockouts) != 0 {
+		t.Fatalf("got %v %v, want no lockout", lockouts, err)
+	}
+
+	attempt, _ := backend.GetLoginAttempt(ctx, account)
+
+	if attempt.Failures != 1 {
+		t.Fatalf("got %d failures, want 1", attempt.Failures)
+	}
+}
+
+func TestSucceedKeepsIpFailures(t *testing.T) {
+	ctx := context.Background()
+	l, backend, _ := newTestLimiter()
+	account := EmailKey("a@example.com")
+
+	for range 3 {
+		if _, err := l.Fail(ctx, "10.0.0.1", account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := l.Succeed(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+
+	if attempt, _ := backend.GetLoginAttempt(ctx, account); attempt.Failures != 0 {
+		t.Fatalf("account kept %d failures", attempt.Failures)
+	}
+
+	if attempt, _ := backend.GetLoginAttempt(ctx, "ip:10.0.0.1"); attempt.Failures != 3 {
+		t.Fatalf("ip has %d failures, want 3", attempt.Failures)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	if got := EmailKey(" A@Example.com "); got != "email:a@example.com" {
+		t.Fatalf("got %q", got)
+	}
+
+	if EmailKey("a@example.com") == TotpKey(1) {
+		t.Fatal("email and totp keys must differ")
+	}
+}
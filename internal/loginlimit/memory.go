@@ -0,0 +1,96 @@
+package loginlimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+)
+
+// Memory counts login attempts in the process, for a single replica.
+type Memory struct {
+	mu       sync.Mutex
+	attempts map[string]entity.LoginAttempt
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		attempts: map[string]entity.LoginAttempt{},
+	}
+}
+
+// GetLoginAttempt returns the zero attempt for a key without failures.
+func (m *Memory) GetLoginAttempt(ctx context.Context, key string) (entity.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempt, ok := m.attempts[key]
+
+	if !ok {
+		return entity.LoginAttempt{Key: key}, nil
+	}
+
+	return attempt, nil
+}
+
+func (m *Memory) RecordLoginFailure(ctx context.Context, key string, now time.Time, window time.Duration) (entity.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempt, ok := m.attempts[key]
+
+	if !ok || now.Sub(attempt.LastFailureAt) >= window {
+		attempt.Key = key
+		attempt.Failures = 0
+	}
+
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	m.attempts[key] = attempt
+
+	return attempt, nil
+}
+
+func (m *Memory) LockLogin(ctx context.Context, key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempt, ok := m.attempts[key]
+
+	if !ok {
+		attempt = entity.LoginAttempt{Key: key, LastFailureAt: time.Now()}
+	}
+
+	attempt.LockedUntil = &until
+	m.attempts[key] = attempt
+
+	return nil
+}
+
+func (m *Memory) ResetLoginAttempts(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.attempts, key)
+
+	return nil
+}
+
+// PruneLoginAttempts forgets the keys that failed last before before and aren't
+// locked anymore.
+func (m *Memory) PruneLoginAttempts(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var pruned int64
+
+	for key, attempt := range m.attempts {
+		if attempt.LastFailureAt.Before(before) && (attempt.LockedUntil == nil || attempt.LockedUntil.Before(before)) {
+			delete(m.attempts, key)
+			pruned++
+		}
+	}
+
+	return pruned, nil
+}
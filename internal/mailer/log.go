@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer stands in for an SMTP server: messages are appended to a file, or only
+// their recipient and subject are logged when there is no file. Bodies carry secrets
+// such as reset tokens, so they never go to the log.
+type LogMailer struct {
+	path   string
+	logger *zap.Logger
+	mu     sync.Mutex
+}
+
+func NewLogMailer(path string, logger *zap.Logger) *LogMailer {
+	return &LogMailer{
+		path:   path,
+		logger: logger,
+	}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	if m.path == "" {
+		m.logger.Info("✅ Email not sent, set MAIL_LOG_FILE to read it", zap.String("To", msg.To), zap.String("Subject", msg.Subject))
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+
+	return err
+}
@@ -0,0 +1,53 @@
+// Package mailer sends the transactional emails of LogStream, e.g. password resets.
+package mailer
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+var ErrNoSMTPHost = errors.New("mailer: SMTP_HOST is required in production")
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends messages, implementations must be safe for concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type Config struct {
+	// SMTP server, e.g. smtp.example.com. When empty messages go to LogFile or the log
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// sender address, e.g. LogStream <no-reply@example.com>
+	From string
+	// file the messages are appended to when there is no SMTP server, for local
+	// development and tests
+	LogFile string
+	// refuse the LogMailer, emails carry reset and verification tokens
+	Production bool
+}
+
+// New returns the SMTP mailer of cfg, or a LogMailer when no SMTP host is set outside
+// production.
+func New(cfg Config, logger *zap.Logger) (Mailer, error) {
+	if cfg.SMTPHost == "" {
+		if cfg.Production {
+			return nil, ErrNoSMTPHost
+		}
+
+		logger.Warn("⚠️ SMTP_HOST is not set, emails are not sent", zap.String("LogFile", cfg.LogFile))
+		return NewLogMailer(cfg.LogFile, logger), nil
+	}
+
+	return NewSMTPMailer(cfg), nil
+}
@@ -0,0 +1,116 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    any
+		wantErr error
+	}{
+		{"smtp", Config{SMTPHost: "smtp.example.com"}, &SMTPMailer{}, nil},
+		{"smtp in production", Config{SMTPHost: "smtp.example.com", Production: true}, &SMTPMailer{}, nil},
+		{"no smtp", Config{}, &LogMailer{}, nil},
+		{"no smtp in production", Config{LogFile: "mail.log", Production: true}, nil, ErrNoSMTPHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.cfg, zap.NewNop())
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			switch tt.want.(type) {
+			case *SMTPMailer:
+				if _, ok := m.(*SMTPMailer); !ok {
+					t.Fatalf("got %T, want *SMTPMailer", m)
+				}
+			case *LogMailer:
+				if _, ok := m.(*LogMailer); !ok {
+					t.Fatalf("got %T, want *LogMailer", m)
+				}
+			default:
+				if m != nil {
+					t.Fatalf("got %T, want no mailer", m)
+				}
+			}
+		})
+	}
+}
+
+func TestLogMailerNeverLogsBody(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	m := NewLogMailer("", zap.New(core))
+
+	err := m.Send(context.Background(), Message{To: "a@example.com", Subject: "Reset", Body: "token secret-token"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range logs.All() {
+		if strings.Contains(entry.Message, "secret-token") {
+			t.Fatalf("message %q logs the body", entry.Message)
+		}
+
+		for key, value := range entry.ContextMap() {
+			if strings.Contains(toString(value), "secret-token") {
+				t.Fatalf("field %s logs the body", key)
+			}
+		}
+	}
+}
+
+func toString(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func TestLogMailerAppendsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	m := NewLogMailer(path, zap.NewNop())
+
+	for _, to := range []string{"a@example.com", "b@example.com"} {
+		err := m.Send(context.Background(), Message{To: to, Subject: "Reset", Body: "token for " + to})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"To: a@example.com", "token for a@example.com", "To: b@example.com", "token for b@example.com"} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("mail log misses %q:\n%s", want, b)
+		}
+	}
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mail log mode is %v, want 0600", info.Mode().Perm())
+	}
+}
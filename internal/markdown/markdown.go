@@ -0,0 +1,96 @@
+// Package markdown renders post content, which is stored as raw Markdown, for the
+// places it is shown: sanitized HTML for API responses and public pages, and plain
+// text or Slack mrkdwn for webhook providers.
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	md = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		// changelog entries are often written line by line, keep their line breaks
+		goldmark.WithRendererOptions(html.WithHardWraps()),
+	)
+
+	// goldmark already omits raw HTML, the policy also drops unsafe urls and attributes
+	policy = newPolicy()
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+
+	// task list items of GFM
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return p
+}
+
+func parse(src string) (ast.Node, []byte) {
+	source := []byte(src)
+	return md.Parser().Parse(text.NewReader(source)), source
+}
+
+// ToHTML renders src as sanitized HTML, safe to embed in a page as is.
+func ToHTML(src string) string {
+	var b bytes.Buffer
+
+	if err := md.Convert([]byte(src), &b); err != nil {
+		// goldmark only fails on writer errors, which bytes.Buffer doesn't return
+		return policy.Sanitize(src)
+	}
+
+	return policy.Sanitize(b.String())
+}
+
+// PlainText strips the Markdown syntax of src, links keep their text.
+func PlainText(src string) string {
+	doc, source := parse(src)
+
+	w := &textWriter{source: source}
+	w.render(doc)
+
+	return w.String()
+}
+
+// ToSlack converts src to Slack mrkdwn.
+func ToSlack(src string) string {
+	doc, source := parse(src)
+
+	w := &textWriter{source: source, mrkdwn: true}
+	w.render(doc)
+
+	return w.String()
+}
+
+// EscapeSlack escapes the characters Slack mrkdwn gives a meaning (&, <, >) in plain
+// text, so e.g. <!channel> in a title doesn't ping anyone.
+func EscapeSlack(s string) string {
+	return mrkdwnEscaper.Replace(s)
+}
+
+// Excerpt is PlainText cut to at most max characters (runes), ending with an
+// ellipsis when it was cut.
+func Excerpt(src string, max int) string {
+	plain := PlainText(src)
+
+	if utf8.RuneCountInString(plain) <= max {
+		return plain
+	}
+
+	runes := []rune(plain)
+
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
@@ -0,0 +1,79 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    []string
+		notWant []string
+	}{
+		{"markdown", "# Release\n\n**bold** and _em_", []string{"<h1", "Release</h1>", "<strong>bold</strong>", "<em>em</em>"}, nil},
+		{"script tag", "hi <script>alert(1)</script>", []string{"hi"}, []string{"<script", "alert(1)</script>"}},
+		{"html block", "<div onclick=\"steal()\">x</div>", nil, []string{"<div", "onclick"}},
+		{"javascript link", "[click](javascript:alert(1))", []string{"click"}, []string{"javascript:"}},
+		{"image onerror", "<img src=x onerror=alert(1)>", nil, []string{"onerror"}},
+		{"safe link", "[docs](https://example.com)", []string{`href="https://example.com"`, `rel="nofollow"`}, nil},
+		{"task list", "- [x] done", []string{`type="checkbox"`, "checked"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToHTML(tt.src)
+
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("%q doesn't contain %q", got, s)
+				}
+			}
+
+			for _, s := range tt.notWant {
+				if strings.Contains(got, s) {
+					t.Errorf("%q contains %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestToSlack(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"bold and italic", "**new** _fast_", "*new* _fast_"},
+		{"heading", "# Release", "*Release*"},
+		{"link", "[docs](https://example.com)", "<https://example.com|docs>"},
+		{"escaped", "a < b & c > d", "a &lt; b &amp; c &gt; d"},
+		{"strikethrough", "~~old~~", "~old~"},
+		{"list", "- one\n- two", "• one\n• two"},
+		{"code", "`x := 1`", "`x := 1`"},
+		{"raw html", "<b>x</b>", "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToSlack(tt.src); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	long := strings.Repeat("é", 50)
+
+	if got := Excerpt(long, 10); utf8.RuneCountInString(got) != 10 || !strings.HasSuffix(got, "…") {
+		t.Fatalf("got %q", got)
+	}
+
+	if got := Excerpt("**short**", 10); got != "short" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,200 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+)
+
+var (
+	blankLines    = regexp.MustCompile(`\n{3,}`)
+	mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// textWriter renders a Markdown AST as plain text, or as Slack mrkdwn when mrkdwn
+// is set.
+type textWriter struct {
+	strings.Builder
+	source []byte
+	mrkdwn bool
+	// nesting level of lists
+	depth int
+}
+
+func (w *textWriter) String() string {
+	return strings.TrimSpace(blankLines.ReplaceAllString(w.Builder.String(), "\n\n"))
+}
+
+func (w *textWriter) children(n ast.Node) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		w.render(c)
+	}
+}
+
+func (w *textWriter) text(value []byte) {
+	if w.mrkdwn {
+		w.WriteString(mrkdwnEscaper.Replace(string(value)))
+		return
+	}
+
+	w.Write(value)
+}
+
+// mark writes mrkdwn syntax, it's dropped from plain text.
+func (w *textWriter) mark(s string) {
+	if w.mrkdwn {
+		w.WriteString(s)
+	}
+}
+
+func (w *textWriter) endBlock() {
+	w.WriteString("\n\n")
+}
+
+func (w *textWriter) render(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Paragraph:
+		w.children(n)
+		w.endBlock()
+	case *ast.TextBlock:
+		w.children(n)
+		w.WriteString("\n")
+	case *ast.Heading:
+		w.mark("*")
+		w.children(n)
+		w.mark("*")
+		w.endBlock()
+	case *ast.ThematicBreak:
+		w.WriteString("———")
+		w.endBlock()
+	case *ast.CodeBlock, *ast.FencedCodeBlock:
+		w.mark("```\n")
+		lines := n.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			segment := lines.At(i)
+			w.text(segment.Value(w.source))
+		}
+		w.mark("```")
+		w.endBlock()
+	case *ast.Blockquote:
+		quote := &textWriter{source: w.source, mrkdwn: w.mrkdwn}
+		quote.children(n)
+		for _, line := range strings.Split(quote.String(), "\n") {
+			w.mark("> ")
+			w.WriteString(line)
+			w.WriteString("\n")
+		}
+		w.endBlock()
+	case *ast.List:
+		w.list(n)
+	case *ast.Text:
+		w.text(n.Segment.Value(w.source))
+		if n.SoftLineBreak() || n.HardLineBreak() {
+			w.WriteString("\n")
+		}
+	case *ast.String:
+		w.text(n.Value)
+	case *ast.CodeSpan:
+		w.mark("`")
+		w.children(n)
+		w.mark("`")
+	case *ast.Emphasis:
+		marker := "_"
+		if n.Level == 2 {
+			marker = "*"
+		}
+		w.mark(marker)
+		w.children(n)
+		w.mark(marker)
+	case *east.Strikethrough:
+		w.mark("~")
+		w.children(n)
+		w.mark("~")
+	case *ast.Link:
+		w.link(n, n.Destination)
+	case *ast.Image:
+		w.link(n, n.Destination)
+	case *ast.AutoLink:
+		if w.mrkdwn {
+			w.WriteString("<")
+			w.text(n.URL(w.source))
+			w.WriteString(">")
+			return
+		}
+		w.Write(n.Label(w.source))
+	case *ast.RawHTML, *ast.HTMLBlock:
+		// raw HTML is omitted, like in ToHTML
+	case *east.TaskCheckBox:
+		if n.IsChecked {
+			w.WriteString("[x] ")
+		} else {
+			w.WriteString("[ ] ")
+		}
+	case *east.Table:
+		w.children(n)
+		w.endBlock()
+	case *east.TableHeader, *east.TableRow:
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			if c != n.FirstChild() {
+				w.WriteString(" | ")
+			}
+			w.render(c)
+		}
+		w.WriteString("\n")
+	default:
+		w.children(n)
+	}
+}
+
+// link writes <destination|text> in mrkdwn, and "text (destination)" in plain text
+// unless the text already is the destination.
+func (w *textWriter) link(n ast.Node, destination []byte) {
+	if w.mrkdwn {
+		w.WriteString("<")
+		w.text(destination)
+		w.WriteString("|")
+		w.children(n)
+		w.WriteString(">")
+		return
+	}
+
+	label := &textWriter{source: w.source}
+	label.children(n)
+
+	w.WriteString(label.String())
+
+	if label.String() != string(destination) && len(destination) > 0 {
+		w.WriteString(" (")
+		w.Write(destination)
+		w.WriteString(")")
+	}
+}
+
+func (w *textWriter) list(n *ast.List) {
+	w.depth++
+	indent := strings.Repeat("  ", w.depth-1)
+
+	number := n.Start
+	for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+		w.WriteString(indent)
+
+		if n.IsOrdered() {
+			w.WriteString(strconv.Itoa(number) + ". ")
+			number++
+		} else {
+			w.WriteString("• ")
+		}
+
+		w.children(item)
+	}
+
+	w.depth--
+
+	// a nested list ends with its parent item
+	if _, nested := n.Parent().(*ast.ListItem); !nested {
+		w.endBlock()
+	}
+}
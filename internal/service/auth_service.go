@@ -1,23 +1,62 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"image/png"
+	"net/url"
+	"strings"
 
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/internal/loginlimit"
+	"github.com/ariefzainuri96/go-logstream/internal/mailer"
+	"github.com/ariefzainuri96/go-logstream/internal/sso"
 	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"github.com/pquerna/otp"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AuthConfig struct {
+	Mailer mailer.Mailer
+	// pages of the frontend the emailed tokens are sent to as ?token=, the emails carry
+	// the bare token when empty
+	PasswordResetURL     string
+	EmailVerificationURL string
+	// refuse to log in users who haven't verified their email
+	RequireVerifiedEmail bool
+	// throttles failed logins, nil doesn't throttle
+	LoginLimiter *loginlimit.Limiter
+	// OIDC providers users can log in with, nil has none
+	SSO *sso.SSO
+}
+
+// ErrEmailNotVerified is returned by Login when AuthConfig.RequireVerifiedEmail is set
+// and the user hasn't verified their email.
+var ErrEmailNotVerified = errors.New("email is not verified")
+
+// errors of the 2FA methods, the controllers only know the service
+var (
+	ErrInvalidTotpCode    = store.ErrInvalidTotpCode
+	ErrTotpAlreadyEnabled = store.ErrTotpAlreadyEnabled
+	ErrTotpNotEnrolled    = store.ErrTotpNotEnrolled
+	ErrTotpNotEnabled     = store.ErrTotpNotEnabled
 )
 
 type AuthServiceImpl struct {
 	logger *zap.Logger
 	store  store.Storage
+	cfg    AuthConfig
 }
 
-func NewAuthService(store store.Storage, logger *zap.Logger) *AuthServiceImpl {
+func NewAuthService(store store.Storage, logger *zap.Logger, cfg AuthConfig) *AuthServiceImpl {
 	return &AuthServiceImpl{
 		logger: logger,
 		store:  store,
+		cfg:    cfg,
 	}
 }
 
@@ -28,25 +67,462 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req request.RegisterRequ
 		return 0, err
 	}
 
+	// a failed email can be sent again with RequestEmailVerification
+	_, _, err = s.RequestEmailVerification(ctx, request.ResendVerificationRequest{Email: req.Email})
+
+	if err != nil {
+		s.logger.Error("⚠️ Failed to send verification email", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("UserId", id), zap.Error(err))
+	}
+
+	// invitations wait for VerifyEmail, anyone can register an invited email
 	return id, nil
 }
 
-func (s *AuthServiceImpl) Login(ctx context.Context, req request.LoginRequest) (entity.User, string, error) {
-	user, token, err := s.store.IAuth.Login(ctx, req)
+// Login returns a *loginlimit.ThrottledError, before comparing passwords, while the
+// email or the client IP of ctx failed too often.
+func (s *AuthServiceImpl) Login(ctx context.Context, req request.LoginRequest) (entity.User, entity.AuthTokens, error) {
+	user, err := s.checkPassword(ctx, req)
+
+	if err != nil {
+		return entity.User{}, entity.AuthTokens{}, err
+	}
+
+	if s.cfg.RequireVerifiedEmail && user.EmailVerifiedAt == nil {
+		return entity.User{}, entity.AuthTokens{}, ErrEmailNotVerified
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+// completeLogin logs in user once they are authenticated, with an MFA challenge
+// instead of the tokens when they have 2FA.
+func (s *AuthServiceImpl) completeLogin(ctx context.Context, user entity.User) (entity.User, entity.AuthTokens, error) {
+	// the tokens wait for a TOTP code, see VerifyMfa
+	if user.TotpEnabledAt != nil {
+		mfaToken, err := s.store.IAuth.IssueMfaChallenge(ctx, user)
+
+		if err != nil {
+			return entity.User{}, entity.AuthTokens{}, err
+		}
+
+		return user, entity.AuthTokens{MfaToken: mfaToken}, nil
+	}
+
+	return s.finishLogin(ctx, user)
+}
+
+// finishLogin issues the tokens of user once every check passed.
+func (s *AuthServiceImpl) finishLogin(ctx context.Context, user entity.User) (entity.User, entity.AuthTokens, error) {
+	tokens, err := s.store.IAuth.IssueTokens(ctx, user)
+
+	if err != nil {
+		return entity.User{}, entity.AuthTokens{}, err
+	}
+
+	if s.canAcceptInvites(user) {
+		s.acceptInvites(ctx, user.ID, user.Email)
+	}
+
+	return user, tokens, nil
+}
+
+func (s *AuthServiceImpl) GetUser(ctx context.Context, userId uint) (entity.User, error) {
+	return s.store.IAuth.GetUser(ctx, userId)
+}
+
+func (s *AuthServiceImpl) IssueMfaChallenge(ctx context.Context, user entity.User) (string, error) {
+	return s.store.IAuth.IssueMfaChallenge(ctx, user)
+}
+
+func (s *AuthServiceImpl) ParseMfaChallenge(ctx context.Context, mfaToken string) (entity.User, error) {
+	return s.store.IAuth.ParseMfaChallenge(ctx, mfaToken)
+}
+
+// VerifyMfa is the second step of Login for users with 2FA. Wrong codes count as
+// failed logins of the email, so the login limiter throttles guessing them.
+func (s *AuthServiceImpl) VerifyMfa(ctx context.Context, req request.VerifyMfaRequest) (entity.User, entity.AuthTokens, error) {
+	user, err := s.store.IAuth.ParseMfaChallenge(ctx, req.MfaToken)
+
+	if err != nil {
+		return entity.User{}, entity.AuthTokens{}, err
+	}
+
+	if err := s.checkTotpCode(ctx, user, req.Code); err != nil {
+		return entity.User{}, entity.AuthTokens{}, err
+	}
+
+	return s.finishLogin(ctx, user)
+}
+
+func (s *AuthServiceImpl) CheckTotpCode(ctx context.Context, user entity.User, code string) error {
+	return s.checkTotpCode(ctx, user, code)
+}
+
+// checkTotpCode is CheckTotpCode throttled by the login limiter, like checkPassword.
+func (s *AuthServiceImpl) checkTotpCode(ctx context.Context, user entity.User, code string) error {
+	return s.throttleTotpCode(ctx, user, func() error {
+		return s.store.IAuth.CheckTotpCode(ctx, user, code)
+	})
+}
+
+// throttleTotpCode runs check, a store call using up a 2FA code of user, behind the
+// login limiter: ErrInvalidTotpCode counts as a failure of the 2FA key of user, which
+// a correct password doesn't reset.
+func (s *AuthServiceImpl) throttleTotpCode(ctx context.Context, user entity.User, check func() error) error {
+	if s.cfg.LoginLimiter == nil {
+		return check()
+	}
+
+	reqID := requestIdFromContext(ctx)
+	ip := middleware.ClientIPFromContext(ctx)
+
+	account := loginlimit.TotpKey(user.ID)
+
+	err := s.cfg.LoginLimiter.Check(ctx, ip, account)
+
+	var throttled *loginlimit.ThrottledError
+
+	if errors.As(err, &throttled) {
+		s.logger.Warn("⚠️ 2FA code throttled", zap.String("RequestId", reqID), zap.Uint("UserId", user.ID), zap.String("IP", ip), zap.Bool("Locked", throttled.Locked), zap.Duration("RetryAfter", throttled.RetryAfter))
+		return err
+	}
+
+	if err != nil {
+		s.logger.Error("⚠️ Failed to check login attempts", zap.String("RequestId", reqID), zap.Error(err))
+	}
+
+	err = check()
+
+	if errors.Is(err, store.ErrInvalidTotpCode) {
+		s.loginFailed(ctx, ip, account)
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.cfg.LoginLimiter.Succeed(ctx, account); err != nil {
+		s.logger.Error("⚠️ Failed to reset login attempts", zap.String("RequestId", reqID), zap.Uint("UserId", user.ID), zap.Error(err))
+	}
+
+	return nil
+}
+
+// EnrollTotp returns a new pending TOTP secret of the user with its QR code.
+func (s *AuthServiceImpl) EnrollTotp(ctx context.Context, userId uint) (entity.TotpEnrollment, error) {
+	enrollment, err := s.store.IAuth.EnrollTotp(ctx, userId)
+
+	if err != nil {
+		return entity.TotpEnrollment{}, err
+	}
+
+	key, err := otp.NewKeyFromURL(enrollment.URI)
+
+	if err != nil {
+		return entity.TotpEnrollment{}, err
+	}
+
+	img, err := key.Image(256, 256)
+
+	if err != nil {
+		return entity.TotpEnrollment{}, err
+	}
+
+	var qrCode bytes.Buffer
+
+	if err := png.Encode(&qrCode, img); err != nil {
+		return entity.TotpEnrollment{}, err
+	}
+
+	enrollment.QRCode = qrCode.Bytes()
+
+	return enrollment, nil
+}
+
+// ConfirmTotp and DisableTotp check their code behind the login limiter like VerifyMfa,
+// a stolen access token mustn't allow guessing codes.
+func (s *AuthServiceImpl) ConfirmTotp(ctx context.Context, userId uint, req request.TotpCodeRequest) ([]string, error) {
+	user, err := s.store.IAuth.GetUser(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []string
+
+	err = s.throttleTotpCode(ctx, user, func() error {
+		codes, err = s.store.IAuth.ConfirmTotp(ctx, userId, req)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("✅ 2FA enabled", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("UserId", userId))
+
+	return codes, nil
+}
+
+func (s *AuthServiceImpl) DisableTotp(ctx context.Context, userId uint, req request.TotpCodeRequest) error {
+	user, err := s.store.IAuth.GetUser(ctx, userId)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.throttleTotpCode(ctx, user, func() error {
+		return s.store.IAuth.DisableTotp(ctx, userId, req)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("✅ 2FA disabled", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("UserId", userId))
+
+	return nil
+}
+
+func (s *AuthServiceImpl) CheckPassword(ctx context.Context, req request.LoginRequest) (entity.User, error) {
+	return s.checkPassword(ctx, req)
+}
+
+// checkPassword is CheckPassword throttled by the login limiter.
+func (s *AuthServiceImpl) checkPassword(ctx context.Context, req request.LoginRequest) (entity.User, error) {
+	if s.cfg.LoginLimiter == nil {
+		return s.store.IAuth.CheckPassword(ctx, req)
+	}
+
+	reqID := requestIdFromContext(ctx)
+	ip := middleware.ClientIPFromContext(ctx)
+
+	account := loginlimit.EmailKey(req.Email)
+
+	err := s.cfg.LoginLimiter.Check(ctx, ip, account)
+
+	var throttled *loginlimit.ThrottledError
+
+	if errors.As(err, &throttled) {
+		s.logger.Warn("⚠️ Login throttled", zap.String("RequestId", reqID), zap.String("Email", req.Email), zap.String("IP", ip), zap.Bool("Locked", throttled.Locked), zap.Duration("RetryAfter", throttled.RetryAfter))
+		return entity.User{}, err
+	}
+
+	// a broken limiter backend mustn't lock everyone out
+	if err != nil {
+		s.logger.Error("⚠️ Failed to check login attempts", zap.String("RequestId", reqID), zap.Error(err))
+	}
+
+	user, err := s.store.IAuth.CheckPassword(ctx, req)
+
+	if err != nil {
+		// unknown emails count too, they fail like wrong passwords
+		s.loginFailed(ctx, ip, account)
+		return entity.User{}, err
+	}
+
+	if err := s.cfg.LoginLimiter.Succeed(ctx, account); err != nil {
+		s.logger.Error("⚠️ Failed to reset login attempts", zap.String("RequestId", reqID), zap.Uint("UserId", user.ID), zap.Error(err))
+	}
+
+	return user, nil
+}
+
+func (s *AuthServiceImpl) loginFailed(ctx context.Context, ip string, account string) {
+	reqID := requestIdFromContext(ctx)
+
+	lockouts, err := s.cfg.LoginLimiter.Fail(ctx, ip, account)
+
+	if err != nil {
+		s.logger.Error("⚠️ Failed to record failed login", zap.String("RequestId", reqID), zap.Error(err))
+	}
+
+	for _, lockout := range lockouts {
+		s.logger.Warn("🔒 Login locked out",
+			zap.String("RequestId", reqID),
+			zap.String("Event", "login.lockout"),
+			zap.String("Key", lockout.Key),
+			zap.String("IP", ip),
+			zap.Int("Failures", lockout.Failures),
+			zap.Time("LockedUntil", lockout.Until))
+	}
+}
+
+// IssueTokens logs user in without any check, it is only for flows that already
+// authenticated the user.
+func (s *AuthServiceImpl) IssueTokens(ctx context.Context, user entity.User) (entity.AuthTokens, error) {
+	return s.store.IAuth.IssueTokens(ctx, user)
+}
+
+// canAcceptInvites reports whether invitations sent to the email of user can be
+// accepted: only a verified email proves the user is the one who was invited, even
+// when RequireVerifiedEmail lets unverified users log in.
+func (s *AuthServiceImpl) canAcceptInvites(user entity.User) bool {
+	return user.EmailVerifiedAt != nil
+}
+
+// RequestEmailVerification emails a verification link to req.Email. Unknown and
+// already verified emails are skipped without an error, the caller can't tell which
+// emails are registered. The token is never returned.
+func (s *AuthServiceImpl) RequestEmailVerification(ctx context.Context, req request.ResendVerificationRequest) (entity.User, string, error) {
+	reqID := requestIdFromContext(ctx)
+
+	user, token, err := s.store.IAuth.RequestEmailVerification(ctx, req)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.logger.Info("Email verification requested for an unknown email", zap.String("RequestId", reqID))
+		return entity.User{}, "", nil
+	}
 
 	if err != nil {
 		return entity.User{}, "", err
 	}
 
-	return user, token, nil
+	if user.EmailVerifiedAt != nil {
+		return user, "", nil
+	}
+
+	err = s.cfg.Mailer.Send(ctx, tokenMessage(
+		user.Email,
+		"Verify your LogStream email",
+		"Welcome to LogStream! Confirm this email belongs to you.",
+		s.cfg.EmailVerificationURL,
+		token,
+		"It expires in 48 hours. If you didn't create an account, ignore this email.",
+	))
+
+	if err != nil {
+		return entity.User{}, "", err
+	}
+
+	s.logger.Info("✅ Verification email sent", zap.String("RequestId", reqID), zap.Uint("UserId", user.ID))
+
+	return user, "", nil
 }
 
-func (s *AuthServiceImpl) ForgotPassword(ctx context.Context, req request.LoginRequest) (string, error) {
-	msg, err := s.store.IAuth.ForgotPassword(ctx, req)
+func (s *AuthServiceImpl) VerifyEmail(ctx context.Context, req request.VerifyEmailRequest) (entity.User, error) {
+	user, err := s.store.IAuth.VerifyEmail(ctx, req)
 
 	if err != nil {
-		return "", err
+		return entity.User{}, err
 	}
 
-	return msg, nil
+	s.logger.Info("✅ Email verified", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("UserId", user.ID))
+
+	s.acceptInvites(ctx, user.ID, user.Email)
+
+	return user, nil
+}
+
+// Refresh rotates a refresh token. Reuse of a rotated token is logged and reported as
+// gorm.ErrRecordNotFound, like any other refresh token that can't be used.
+func (s *AuthServiceImpl) Refresh(ctx context.Context, refreshToken string) (entity.AuthTokens, error) {
+	tokens, err := s.store.IAuth.Refresh(ctx, refreshToken)
+
+	if errors.Is(err, store.ErrRefreshTokenReused) {
+		s.logger.Warn("⚠️ Refresh token reused, its family is revoked", zap.String("RequestId", requestIdFromContext(ctx)))
+		return entity.AuthTokens{}, gorm.ErrRecordNotFound
+	}
+
+	if err != nil {
+		return entity.AuthTokens{}, err
+	}
+
+	return tokens, nil
+}
+
+func (s *AuthServiceImpl) Logout(ctx context.Context, refreshToken string) error {
+	return s.store.IAuth.Logout(ctx, refreshToken)
+}
+
+func (s *AuthServiceImpl) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return s.store.IAuth.IsTokenRevoked(ctx, jti)
+}
+
+// acceptInvites links the project invitations sent to email before the user
+// registered, once the email is verified. It also runs on login, so invitations a
+// failed attempt left pending are picked up later.
+func (s *AuthServiceImpl) acceptInvites(ctx context.Context, userId uint, email string) {
+	_, err := s.store.IProjectMember.AcceptInvites(ctx, userId, email)
+
+	if err != nil {
+		s.logger.Error("⚠️ Failed to accept project invitations", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("UserId", userId), zap.Error(err))
+	}
+}
+
+// ForgotPassword emails a password reset token to req.Email. Unknown emails and
+// mailer failures are only logged, the caller can't tell which emails are registered.
+func (s *AuthServiceImpl) ForgotPassword(ctx context.Context, req request.ForgotPasswordRequest) (entity.PasswordResetToken, error) {
+	reqID := requestIdFromContext(ctx)
+
+	resetToken, err := s.store.IAuth.ForgotPassword(ctx, req)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.logger.Info("Password reset requested for an unknown email", zap.String("RequestId", reqID))
+		return entity.PasswordResetToken{}, nil
+	}
+
+	if err != nil {
+		return entity.PasswordResetToken{}, err
+	}
+
+	err = s.cfg.Mailer.Send(ctx, tokenMessage(
+		req.Email,
+		"Reset your LogStream password",
+		"Someone asked to reset the password of your LogStream account.",
+		s.cfg.PasswordResetURL,
+		resetToken.Token,
+		"It expires in an hour and works once. If you didn't ask for it, ignore this email.",
+	))
+
+	if err != nil {
+		s.logger.Error("⚠️ Failed to send password reset email", zap.String("RequestId", reqID), zap.Uint("UserId", resetToken.UserId), zap.Error(err))
+		return entity.PasswordResetToken{}, nil
+	}
+
+	s.logger.Info("✅ Password reset email sent", zap.String("RequestId", reqID), zap.Uint("UserId", resetToken.UserId))
+
+	// the token only travels by email
+	resetToken.Token = ""
+
+	return resetToken, nil
+}
+
+// tokenMessage is an email carrying token, as a link to pageURL?token= when pageURL is
+// set.
+func tokenMessage(to string, subject string, intro string, pageURL string, token string, outro string) mailer.Message {
+	var body strings.Builder
+
+	body.WriteString(intro + "\n\n")
+
+	if pageURL != "" {
+		body.WriteString("Open this link to continue:\n" + pageURL + "?token=" + url.QueryEscape(token) + "\n\n")
+	} else {
+		body.WriteString("Use this token to continue:\n" + token + "\n\n")
+	}
+
+	body.WriteString(outro)
+
+	return mailer.Message{
+		To:      to,
+		Subject: subject,
+		Body:    body.String(),
+	}
+}
+
+func (s *AuthServiceImpl) ResetPassword(ctx context.Context, req request.ResetPasswordRequest) (uint, error) {
+	userId, err := s.store.IAuth.ResetPassword(ctx, req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("✅ Password reset", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("UserId", userId))
+
+	return userId, nil
+}
+
+func (s *AuthServiceImpl) LinkIdentity(ctx context.Context, identity entity.UserIdentity) (entity.User, error) {
+	return s.store.IAuth.LinkIdentity(ctx, identity)
 }
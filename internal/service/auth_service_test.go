@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/interfaces"
+	"github.com/ariefzainuri96/go-logstream/internal/loginlimit"
+	"github.com/ariefzainuri96/go-logstream/internal/mailer"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// fakeAuth knows a single user, the embedded interface is nil so a method that isn't
+// faked panics.
+type fakeAuth struct {
+	interfaces.IAuth
+	user entity.User
+	// store calls checking a 2FA code, every code is wrong
+	codeChecks *int
+}
+
+func (s fakeAuth) GetUser(_ context.Context, userId uint) (entity.User, error) {
+	if userId != s.user.ID {
+		return entity.User{}, gorm.ErrRecordNotFound
+	}
+
+	return s.user, nil
+}
+
+func (s fakeAuth) CheckPassword(_ context.Context, req request.LoginRequest) (entity.User, error) {
+	if !strings.EqualFold(req.Email, s.user.Email) || req.Password != "password" {
+		return entity.User{}, errors.New("invalid email or password")
+	}
+
+	return s.user, nil
+}
+
+func (s fakeAuth) CheckTotpCode(context.Context, entity.User, string) error {
+	*s.codeChecks++
+	return store.ErrInvalidTotpCode
+}
+
+func (s fakeAuth) ConfirmTotp(context.Context, uint, request.TotpCodeRequest) ([]string, error) {
+	*s.codeChecks++
+	return nil, store.ErrInvalidTotpCode
+}
+
+func (s fakeAuth) DisableTotp(context.Context, uint, request.TotpCodeRequest) error {
+	*s.codeChecks++
+	return store.ErrInvalidTotpCode
+}
+
+func (s fakeAuth) ForgotPassword(_ context.Context, req request.ForgotPasswordRequest) (entity.PasswordResetToken, error) {
+	if !strings.EqualFold(req.Email, s.user.Email) {
+		return entity.PasswordResetToken{}, gorm.ErrRecordNotFound
+	}
+
+	return entity.PasswordResetToken{UserId: s.user.ID, Token: "reset-token"}, nil
+}
+
+func (s fakeAuth) RequestEmailVerification(_ context.Context, req request.ResendVerificationRequest) (entity.User, string, error) {
+	if !strings.EqualFold(req.Email, s.user.Email) {
+		return entity.User{}, "", gorm.ErrRecordNotFound
+	}
+
+	return s.user, "verify-token", nil
+}
+
+// newMailedAuthService returns an auth service sending its emails to a LogMailer file,
+// read it with the returned func.
+func newMailedAuthService(t *testing.T, auth fakeAuth, cfg AuthConfig) (*AuthServiceImpl, func() string) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	cfg.Mailer = mailer.NewLogMailer(path, zap.NewNop())
+
+	s := NewAuthService(store.Storage{IAuth: auth}, zap.NewNop(), cfg)
+
+	return s, func() string {
+		b, err := os.ReadFile(path)
+
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+
+		return string(b)
+	}
+}
+
+func TestForgotPasswordEmailsToken(t *testing.T) {
+	auth := fakeAuth{user: entity.User{BaseEntity: entity.BaseEntity{ID: 1}, Email: "a@example.com"}}
+	s, mails := newMailedAuthService(t, auth, AuthConfig{PasswordResetURL: "https://app.example.com/reset"})
+
+	token, err := s.ForgotPassword(context.Background(), request.ForgotPasswordRequest{Email: "a@example.com"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.Token != "" {
+		t.Errorf("token %q is returned, it must only travel by email", token.Token)
+	}
+
+	mail := mails()
+
+	for _, want := range []string{"To: a@example.com", "https://app.example.com/reset?token=reset-token"} {
+		if !strings.Contains(mail, want) {
+			t.Errorf("email misses %q:\n%s", want, mail)
+		}
+	}
+}
+
+func TestForgotPasswordUnknownEmail(t *testing.T) {
+	auth := fakeAuth{user: entity.User{BaseEntity: entity.BaseEntity{ID: 1}, Email: "a@example.com"}}
+	s, mails := newMailedAuthService(t, auth, AuthConfig{})
+
+	_, err := s.ForgotPassword(context.Background(), request.ForgotPasswordRequest{Email: "b@example.com"})
+
+	if err != nil {
+		t.Fatalf("unknown email got %v, the caller must not tell it apart", err)
+	}
+
+	if mail := mails(); mail != "" {
+		t.Errorf("unknown email got an email:\n%s", mail)
+	}
+}
+
+func TestRequestEmailVerification(t *testing.T) {
+	auth := fakeAuth{user: entity.User{BaseEntity: entity.BaseEntity{ID: 1}, Email: "a@example.com"}}
+	s, mails := newMailedAuthService(t, auth, AuthConfig{})
+
+	_, token, err := s.RequestEmailVerification(context.Background(), request.ResendVerificationRequest{Email: "a@example.com"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token != "" {
+		t.Errorf("token %q is returned, it must only travel by email", token)
+	}
+
+	if mail := mails(); !strings.Contains(mail, "Use this token to continue:\nverify-token") {
+		t.Errorf("email misses the token:\n%s", mail)
+	}
+}
+
+// TestTotpCodesAreThrottled guesses codes with every call checking one, the limiter must
+// stop them before the store after a few failures. The attacker knows the password and
+// logs in again between guesses, which mustn't reset the failed codes.
+func TestTotpCodesAreThrottled(t *testing.T) {
+	user := entity.User{BaseEntity: entity.BaseEntity{ID: 1}, Email: "a@example.com"}
+
+	calls := []struct {
+		name string
+		call func(ctx context.Context, s *AuthServiceImpl) error
+	}{
+		{"CheckTotpCode", func(ctx context.Context, s *AuthServiceImpl) error {
+			return s.CheckTotpCode(ctx, user, "123456")
+		}},
+		{"ConfirmTotp", func(ctx context.Context, s *AuthServiceImpl) error {
+			_, err := s.ConfirmTotp(ctx, user.ID, request.TotpCodeRequest{Code: "123456"})
+			return err
+		}},
+		{"DisableTotp", func(ctx context.Context, s *AuthServiceImpl) error {
+			return s.DisableTotp(ctx, user.ID, request.TotpCodeRequest{Code: "123456"})
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			codeChecks := 0
+			auth := fakeAuth{user: user, codeChecks: &codeChecks}
+			s := NewAuthService(store.Storage{IAuth: auth}, zap.NewNop(), AuthConfig{
+				LoginLimiter: loginlimit.New(loginlimit.NewMemory(), loginlimit.DefaultConfig()),
+			})
+
+			var throttled *loginlimit.ThrottledError
+
+			for range 10 {
+				_, err := s.CheckPassword(context.Background(), request.LoginRequest{Email: user.Email, Password: "password"})
+
+				if err != nil {
+					t.Fatalf("correct password got %v", err)
+				}
+
+				err = tt.call(context.Background(), s)
+
+				if errors.As(err, &throttled) {
+					break
+				}
+
+				if !errors.Is(err, store.ErrInvalidTotpCode) {
+					t.Fatalf("got %v, want %v", err, store.ErrInvalidTotpCode)
+				}
+			}
+
+			if throttled == nil {
+				t.Fatalf("%d wrong codes were checked without throttling", codeChecks)
+			}
+
+			checked := codeChecks
+
+			if err := tt.call(context.Background(), s); !errors.As(err, &throttled) {
+				t.Fatalf("got %v after throttling, want a *loginlimit.ThrottledError", err)
+			}
+
+			if codeChecks != checked {
+				t.Fatal("a throttled code reached the store")
+			}
+		})
+	}
+}
+
+// fakeRefreshTokens rotates refresh tokens in memory the way store.AuthStore does. Its
+// access tokens aren't signed, an access token is its jti.
+type fakeRefreshTokens struct {
+	interfaces.IAuth
+	// by refresh token
+	tokens map[string]*entity.RefreshToken
+	issued int
+}
+
+func newFakeRefreshTokens() *fakeRefreshTokens {
+	return &fakeRefreshTokens{tokens: map[string]*entity.RefreshToken{}}
+}
+
+func (s *fakeRefreshTokens) issue(familyId string) entity.AuthTokens {
+	s.issued++
+
+	jti := fmt.Sprintf("jti-%d", s.issued)
+	refreshToken := fmt.Sprintf("refresh-%d", s.issued)
+
+	s.tokens[refreshToken] = &entity.RefreshToken{
+		FamilyId:  familyId,
+		AccessJti: jti,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	return entity.AuthTokens{AccessToken: jti, RefreshToken: refreshToken}
+}
+
+func (s *fakeRefreshTokens) Refresh(_ context.Context, refreshToken string) (entity.AuthTokens, error) {
+	current, ok := s.tokens[refreshToken]
+
+	if !ok || current.RevokedAt != nil || time.Now().After(current.ExpiresAt) {
+		return entity.AuthTokens{}, gorm.ErrRecordNotFound
+	}
+
+	now := time.Now()
+
+	if current.UsedAt != nil {
+		for _, token := range s.tokens {
+			if token.FamilyId == current.FamilyId && token.RevokedAt == nil {
+				token.RevokedAt = &now
+			}
+		}
+
+		return entity.AuthTokens{}, store.ErrRefreshTokenReused
+	}
+
+	current.UsedAt = &now
+
+	return s.issue(current.FamilyId), nil
+}
+
+func (s *fakeRefreshTokens) IsTokenRevoked(_ context.Context, jti string) (bool, error) {
+	for _, token := range s.tokens {
+		if token.AccessJti == jti {
+			return token.RevokedAt != nil, nil
+		}
+	}
+
+	return true, nil
+}
+
+func TestRefreshRotates(t *testing.T) {
+	ctx := context.Background()
+	auth := newFakeRefreshTokens()
+	s := NewAuthService(store.Storage{IAuth: auth}, zap.NewNop(), AuthConfig{})
+
+	login := auth.issue("family")
+
+	rotated, err := s.Refresh(ctx, login.RefreshToken)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rotated.RefreshToken == login.RefreshToken || rotated.AccessToken == login.AccessToken {
+		t.Fatal("refresh must issue new tokens")
+	}
+
+	for _, jti := range []string{login.AccessToken, rotated.AccessToken} {
+		if revoked, err := s.IsTokenRevoked(ctx, jti); err != nil || revoked {
+			t.Fatalf("access token %s revoked = %v %v, rotation mustn't revoke it", jti, revoked, err)
+		}
+	}
+
+	if _, err := s.Refresh(ctx, rotated.RefreshToken); err != nil {
+		t.Fatalf("the rotated token got %v", err)
+	}
+}
+
+// TestRefreshReuseRevokesFamily replays a rotated refresh token, as an attacker with a
+// stolen copy would: the whole family is revoked, its access tokens too.
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	auth := newFakeRefreshTokens()
+	s := NewAuthService(store.Storage{IAuth: auth}, zap.NewNop(), AuthConfig{})
+
+	login := auth.issue("family")
+	other := auth.issue("other family")
+
+	rotated, err := s.Refresh(ctx, login.RefreshToken)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Refresh(ctx, login.RefreshToken); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("reuse got %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+
+	if _, err := s.Refresh(ctx, rotated.RefreshToken); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("refresh token of a revoked family got %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+
+	for _, jti := range []string{login.AccessToken, rotated.AccessToken} {
+		if revoked, err := s.IsTokenRevoked(ctx, jti); err != nil || !revoked {
+			t.Fatalf("access token %s revoked = %v %v, want revoked", jti, revoked, err)
+		}
+	}
+
+	if revoked, err := s.IsTokenRevoked(ctx, other.AccessToken); err != nil || revoked {
+		t.Fatalf("another family was revoked: %v %v", revoked, err)
+	}
+}
+
+func TestUnknownAccessTokenIsRevoked(t *testing.T) {
+	s := NewAuthService(store.Storage{IAuth: newFakeRefreshTokens()}, zap.NewNop(), AuthConfig{})
+
+	if revoked, err := s.IsTokenRevoked(context.Background(), "never-issued"); err != nil || !revoked {
+		t.Fatalf("got %v %v, an unknown jti must count as revoked", revoked, err)
+	}
+}
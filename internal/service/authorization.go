@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"gorm.io/gorm"
+)
+
+// ErrInsufficientRole is returned when the caller is a member of the project but
+// their role doesn't allow the operation.
+var ErrInsufficientRole = errors.New("insufficient project role")
+
+// projectRole returns the role of the user authenticated on ctx in a project, reusing
+// the role resolved by middleware.ProjectRoleHandler when there is one. A project the
+// user isn't a member of is gorm.ErrRecordNotFound, like a missing one, so ids of
+// other tenants can't be probed.
+func projectRole(ctx context.Context, store store.Storage, projectId uint) (string, error) {
+	if role, ok := middleware.ProjectRoleFromContext(ctx, projectId); ok {
+		return role, nil
+	}
+
+	userId, ok := middleware.UserIdFromContext(ctx)
+
+	if !ok {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	return store.IProjectMember.GetMemberRole(ctx, projectId, userId)
+}
+
+// authorizeProject returns the project when the caller authenticated on ctx has at
+// least the minimum role in it, see checkProjectRole.
+func authorizeProject(ctx context.Context, store store.Storage, projectId uint, minimum string) (entity.Project, error) {
+	err := checkProjectRole(ctx, store, projectId, minimum)
+
+	if err != nil {
+		return entity.Project{}, err
+	}
+
+	return store.IProject.GetProjectById(ctx, projectId)
+}
+
+// checkProjectRole returns nil when the caller authenticated on ctx has at least the
+// minimum role in the project. A project API key stands for the role of its scopes in
+// its own project, other projects are gorm.ErrRecordNotFound for it.
+func checkProjectRole(ctx context.Context, store store.Storage, projectId uint, minimum string) error {
+	if apiKey, ok := middleware.ApiKeyFromContext(ctx); ok {
+		if apiKey.ProjectId != projectId {
+			return gorm.ErrRecordNotFound
+		}
+
+		if !apiKey.AllowsRole(minimum) {
+			return ErrInsufficientRole
+		}
+
+		return nil
+	}
+
+	role, err := projectRole(ctx, store, projectId)
+
+	if err != nil {
+		return err
+	}
+
+	if !entity.HasProjectRole(role, minimum) {
+		return ErrInsufficientRole
+	}
+
+	return nil
+}
+
+// authorizePost returns the post when the caller authenticated on ctx has at least
+// the minimum role in its project, see checkProjectRole.
+func authorizePost(ctx context.Context, store store.Storage, postId uint, minimum string) (entity.Post, error) {
+	post, err := store.IPost.GetPostById(ctx, postId)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	err = checkProjectRole(ctx, store, post.ProjectId, minimum)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	return post, nil
+}
+
+// orgRole is projectRole for orgs.
+func orgRole(ctx context.Context, store store.Storage, orgId uint) (string, error) {
+	if role, ok := middleware.OrgRoleFromContext(ctx, orgId); ok {
+		return role, nil
+	}
+
+	userId, ok := middleware.UserIdFromContext(ctx)
+
+	if !ok {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	return store.IOrganization.GetOrgMemberRole(ctx, orgId, userId)
+}
+
+// authorizeOrg returns nil when the user authenticated on ctx has at least the minimum
+// role in the org, org roles use the project roles.
+func authorizeOrg(ctx context.Context, store store.Storage, orgId uint, minimum string) error {
+	role, err := orgRole(ctx, store, orgId)
+
+	if err != nil {
+		return err
+	}
+
+	if !entity.HasProjectRole(role, minimum) {
+		return ErrInsufficientRole
+	}
+
+	return nil
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
+	"github.com/ariefzainuri96/go-logstream/internal/interfaces"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Two tenants: user 1 owns project 1 with post 11, webhook 101 and delivery 1001, user
+// 2 owns project 2 with post 22, webhook 202 and delivery 2002. The fakes only know
+// the ids, the services must keep each tenant to its own.
+const (
+	userA    uint = 1
+	projectA uint = 1
+	postA    uint = 11
+	userB    uint = 2
+	projectB uint = 2
+	postB    uint = 22
+)
+
+type tenantFixture struct {
+	// project id -> user id -> role
+	members map[uint]map[uint]string
+	// post id -> project id
+	posts map[uint]uint
+	// webhook or delivery id -> project id
+	webhooks   map[uint]uint
+	deliveries map[uint]uint
+}
+
+func newTenantFixture() *tenantFixture {
+	return &tenantFixture{
+		members: map[uint]map[uint]string{
+			projectA: {userA: entity.ProjectRoleOwner},
+			projectB: {userB: entity.ProjectRoleOwner},
+		},
+		posts:      map[uint]uint{postA: projectA, postB: projectB},
+		webhooks:   map[uint]uint{webhookOf(projectA): projectA, webhookOf(projectB): projectB},
+		deliveries: map[uint]uint{deliveryOf(projectA): projectA, deliveryOf(projectB): projectB},
+	}
+}
+
+func webhookOf(projectId uint) uint {
+	return projectId*100 + projectId
+}
+
+func deliveryOf(projectId uint) uint {
+	return projectId*1000 + projectId
+}
+
+func (f *tenantFixture) storage() store.Storage {
+	return store.Storage{
+		IProject:         fakeProjects{f: f},
+		IPost:            fakePosts{f: f},
+		IProjectMember:   fakeMembers{f: f},
+		IProjectWebhook:  fakeWebhooks{f: f},
+		IWebhookDelivery: fakeDeliveries{f: f},
+		IWebhookQueue:    fakeQueue{},
+	}
+}
+
+// the embedded interfaces are nil, a method that isn't faked panics
+
+type fakeProjects struct {
+	interfaces.IProject
+	f *tenantFixture
+}
+
+func (s fakeProjects) GetProjectById(_ context.Context, projectId uint) (entity.Project, error) {
+	if _, ok := s.f.members[projectId]; !ok {
+		return entity.Project{}, gorm.ErrRecordNotFound
+	}
+
+	return entity.Project{UpdateEntity: entity.UpdateEntity{BaseEntity: entity.BaseEntity{ID: projectId}}}, nil
+}
+
+func (s fakeProjects) UpdateProject(ctx context.Context, projectId uint, _ request.AddProjectRequest) (entity.Project, error) {
+	return s.GetProjectById(ctx, projectId)
+}
+
+func (s fakeProjects) DeleteProject(context.Context, uint) error {
+	return nil
+}
+
+func (s fakeProjects) GetWebhookSecret(context.Context, uint) (string, error) {
+	return "whsec_test", nil
+}
+
+func (s fakeProjects) RotateWebhookSecret(context.Context, uint) (string, error) {
+	return "whsec_test", nil
+}
+
+type fakePosts struct {
+	interfaces.IPost
+	f *tenantFixture
+}
+
+func (s fakePosts) GetPostById(_ context.Context, postId uint) (entity.Post, error) {
+	projectId, ok := s.f.posts[postId]
+
+	if !ok {
+		return entity.Post{}, gorm.ErrRecordNotFound
+	}
+
+	return entity.Post{
+		UpdateEntity: entity.UpdateEntity{BaseEntity: entity.BaseEntity{ID: postId}},
+		ProjectId:    projectId,
+		Status:       "draft",
+	}, nil
+}
+
+func (s fakePosts) CreatePost(_ context.Context, req request.AddPostRequest) (entity.Post, error) {
+	return entity.Post{ProjectId: req.ProjectId, Status: "draft"}, nil
+}
+
+func (s fakePosts) GetPost(context.Context, request.GetPostRequest) (utils.PaginateResult[entity.Post], error) {
+	return utils.PaginateResult[entity.Post]{}, nil
+}
+
+func (s fakePosts) UpdatePost(ctx context.Context, postId uint, _ request.UpdatePostRequest) (entity.Post, error) {
+	return s.GetPostById(ctx, postId)
+}
+
+func (s fakePosts) PatchPost(ctx context.Context, postId uint, _ request.PatchPostRequest) (entity.Post, error) {
+	return s.GetPostById(ctx, postId)
+}
+
+func (s fakePosts) DeletePost(context.Context, uint) error {
+	return nil
+}
+
+func (s fakePosts) PublishPost(ctx context.Context, postId uint) (entity.Post, error) {
+	return s.GetPostById(ctx, postId)
+}
+
+type fakeMembers struct {
+	interfaces.IProjectMember
+	f *tenantFixture
+}
+
+func (s fakeMembers) GetMemberRole(_ context.Context, projectId uint, userId uint) (string, error) {
+	role, ok := s.f.members[projectId][userId]
+
+	if !ok {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	return role, nil
+}
+
+type fakeWebhooks struct {
+	interfaces.IProjectWebhook
+	f *tenantFixture
+}
+
+func (s fakeWebhooks) GetWebhooks(context.Context, uint) ([]entity.ProjectWebhook, error) {
+	return nil, nil
+}
+
+func (s fakeWebhooks) GetWebhook(_ context.Context, projectId uint, webhookId uint) (entity.ProjectWebhook, error) {
+	if s.f.webhooks[webhookId] != projectId {
+		return entity.ProjectWebhook{}, gorm.ErrRecordNotFound
+	}
+
+	return entity.ProjectWebhook{UpdateEntity: entity.UpdateEntity{BaseEntity: entity.BaseEntity{ID: webhookId}}, ProjectId: projectId}, nil
+}
+
+func (s fakeWebhooks) AddWebhook(_ context.Context, projectId uint, _ request.AddProjectWebhookRequest) (entity.ProjectWebhook, error) {
+	return entity.ProjectWebhook{ProjectId: projectId}, nil
+}
+
+func (s fakeWebhooks) UpdateWebhook(ctx context.Context, projectId uint, webhookId uint, _ request.AddProjectWebhookRequest) (entity.ProjectWebhook, error) {
+	return s.GetWebhook(ctx, projectId, webhookId)
+}
+
+func (s fakeWebhooks) DeleteWebhook(ctx context.Context, projectId uint, webhookId uint) error {
+	_, err := s.GetWebhook(ctx, projectId, webhookId)
+	return err
+}
+
+// no webhook is subscribed, so post events enqueue nothing
+func (s fakeWebhooks) GetSubscribedWebhooks(context.Context, uint, string) ([]entity.ProjectWebhook, error) {
+	return nil, nil
+}
+
+type fakeDeliveries struct {
+	interfaces.IWebhookDelivery
+	f *tenantFixture
+}
+
+func (s fakeDeliveries) GetDeliveries(context.Context, uint, request.GetWebhookDeliveryRequest) (utils.PaginateResult[entity.WebhookDelivery], error) {
+	return utils.PaginateResult[entity.WebhookDelivery]{}, nil
+}
+
+func (s fakeDeliveries) GetDelivery(_ context.Context, projectId uint, deliveryId uint) (entity.WebhookDelivery, error) {
+	if s.f.deliveries[deliveryId] != projectId {
+		return entity.WebhookDelivery{}, gorm.ErrRecordNotFound
+	}
+
+	return entity.WebhookDelivery{ProjectId: projectId}, nil
+}
+
+func (s fakeDeliveries) RedeliverDelivery(ctx context.Context, projectId uint, deliveryId uint) (entity.WebhookDelivery, error) {
+	return s.GetDelivery(ctx, projectId, deliveryId)
+}
+
+type fakeQueue struct {
+	interfaces.IWebhookQueue
+}
+
+func userContext(userId uint) context.Context {
+	return context.WithValue(context.Background(), middleware.UserContextKey, map[string]any{"user_id": userId})
+}
+
+func apiKeyContext(projectId uint) context.Context {
+	return context.WithValue(context.Background(), middleware.ApiKeyContextKey, entity.ProjectApiKey{
+		ProjectId: projectId,
+		Scopes:    []string{entity.ApiKeyScopePostsRead, entity.ApiKeyScopePostsWrite},
+	})
+}
+
+// tenantCase calls a service on the resources of project, post belongs to it.
+type tenantCase struct {
+	name string
+	call func(ctx context.Context, s Service, project uint, post uint) error
+}
+
+var tenantCases = []tenantCase{
+	// projects
+	{"GetProjectById", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IProject.GetProjectById(ctx, project)
+		return err
+	}},
+	{"UpdateProject", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IProject.UpdateProject(ctx, project, request.AddProjectRequest{})
+		return err
+	}},
+	{"DeleteProject", func(ctx context.Context, s Service, project uint, _ uint) error {
+		return s.IProject.DeleteProject(ctx, project)
+	}},
+	{"GetWebhookSecret", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IProject.GetWebhookSecret(ctx, project)
+		return err
+	}},
+	{"RotateWebhookSecret", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IProject.RotateWebhookSecret(ctx, project)
+		return err
+	}},
+
+	// posts
+	{"CreatePost", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IPost.CreatePost(ctx, request.AddPostRequest{ProjectId: project})
+		return err
+	}},
+	{"GetPost", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IPost.GetPost(ctx, request.GetPostRequest{ProjectId: project})
+		return err
+	}},
+	{"GetPostById", func(ctx context.Context, s Service, _ uint, post uint) error {
+		_, err := s.IPost.GetPostById(ctx, post)
+		return err
+	}},
+	{"UpdatePost", func(ctx context.Context, s Service, _ uint, post uint) error {
+		_, err := s.IPost.UpdatePost(ctx, post, request.UpdatePostRequest{})
+		return err
+	}},
+	{"PatchPost", func(ctx context.Context, s Service, _ uint, post uint) error {
+		_, err := s.IPost.PatchPost(ctx, post, request.PatchPostRequest{})
+		return err
+	}},
+	{"DeletePost", func(ctx context.Context, s Service, _ uint, post uint) error {
+		return s.IPost.DeletePost(ctx, post)
+	}},
+	{"PublishPost", func(ctx context.Context, s Service, _ uint, post uint) error {
+		_, err := s.IPost.PublishPost(ctx, post)
+		return err
+	}},
+	{"UnpublishPost", func(ctx context.Context, s Service, _ uint, post uint) error {
+		_, err := s.IPost.UnpublishPost(ctx, post)
+		return err
+	}},
+
+	// webhooks
+	{"GetWebhooks", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IProjectWebhook.GetWebhooks(ctx, project)
+		return err
+	}},
+	{"GetWebhook", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IProjectWebhook.GetWebhook(ctx, project, webhookOf(project))
+		return err
+	}},
+	{"AddWebhook", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IProjectWebhook.AddWebhook(ctx, project, request.AddProjectWebhookRequest{})
+		return err
+	}},
+	{"UpdateWebhook", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IProjectWebhook.UpdateWebhook(ctx, project, webhookOf(project), request.AddProjectWebhookRequest{})
+		return err
+	}},
+	{"DeleteWebhook", func(ctx context.Context, s Service, project uint, _ uint) error {
+		return s.IProjectWebhook.DeleteWebhook(ctx, project, webhookOf(project))
+	}},
+	{"PreviewTemplate", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IWebhookTester.PreviewTemplate(ctx, project, request.PreviewWebhookTemplateRequest{PayloadTemplate: `{}`})
+		return err
+	}},
+	{"TestWebhook", func(ctx context.Context, s Service, project uint, _ uint) error {
+		// the tenant itself gets as far as dialing, which refuses loopback
+		_, err := s.IWebhookTester.TestWebhook(ctx, project, request.TestWebhookRequest{Url: "http://127.0.0.1:1"})
+		return err
+	}},
+
+	// deliveries
+	{"GetDeliveries", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IWebhookDelivery.GetDeliveries(ctx, project, request.GetWebhookDeliveryRequest{})
+		return err
+	}},
+	{"GetDelivery", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IWebhookDelivery.GetDelivery(ctx, project, deliveryOf(project))
+		return err
+	}},
+	{"RedeliverDelivery", func(ctx context.Context, s Service, project uint, _ uint) error {
+		_, err := s.IWebhookDelivery.RedeliverDelivery(ctx, project, deliveryOf(project))
+		return err
+	}},
+}
+
+func newTenantService() Service {
+	return NewService(newTenantFixture().storage(), zap.NewNop(), AuthConfig{})
+}
+
+func TestCrossTenantAccessIsNotFound(t *testing.T) {
+	s := newTenantService()
+
+	callers := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"user of another tenant", userContext(userB)},
+		{"api key of another project", apiKeyContext(projectB)},
+		{"no caller", context.Background()},
+	}
+
+	for _, caller := range callers {
+		for _, tt := range tenantCases {
+			t.Run(caller.name+"/"+tt.name, func(t *testing.T) {
+				err := tt.call(caller.ctx, s, projectA, postA)
+
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					t.Fatalf("got %v, want %v", err, gorm.ErrRecordNotFound)
+				}
+			})
+		}
+	}
+}
+
+// TestOwnTenantAccess makes sure the fixture reaches the stores for the tenant itself, so
+// the not found above comes from the authorization.
+func TestOwnTenantAccess(t *testing.T) {
+	s := newTenantService()
+
+	for _, tt := range tenantCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(userContext(userB), s, projectB, postB)
+
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Fatalf("tenant got %v on its own resources", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/sso"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"go.uber.org/zap"
+)
+
+// errors of the OIDC login, the controllers only know the service
+var (
+	ErrOidcUnknownProvider      = sso.ErrUnknownProvider
+	ErrOidcInvalidFlow          = sso.ErrInvalidFlow
+	ErrIdentityEmailNotVerified = store.ErrIdentityEmailNotVerified
+)
+
+type OidcServiceImpl struct {
+	logger *zap.Logger
+	store  store.Storage
+	// logs in the linked users like a password login
+	auth *AuthServiceImpl
+	sso  *sso.SSO
+}
+
+func NewOidcService(store store.Storage, logger *zap.Logger, auth *AuthServiceImpl, sso *sso.SSO) *OidcServiceImpl {
+	return &OidcServiceImpl{
+		logger: logger,
+		store:  store,
+		auth:   auth,
+		sso:    sso,
+	}
+}
+
+// StartOidc returns the url of provider to send the user to, and the flow to hand back
+// to FinishOidc.
+func (s *OidcServiceImpl) StartOidc(ctx context.Context, provider string) (string, string, error) {
+	if s.sso == nil {
+		return "", "", ErrOidcUnknownProvider
+	}
+
+	authURL, flow, err := s.sso.Start(ctx, provider)
+
+	if err != nil && !errors.Is(err, ErrOidcUnknownProvider) {
+		s.logger.Error("⚠️ Failed to start OIDC login", zap.String("RequestId", requestIdFromContext(ctx)), zap.String("Provider", provider), zap.Error(err))
+	}
+
+	return authURL, flow, err
+}
+
+// FinishOidc logs in the user provider redirected back with, linking the identity to
+// an account on its first login. Users with 2FA get an MFA challenge like on Login.
+func (s *OidcServiceImpl) FinishOidc(ctx context.Context, provider string, flow string, req request.OidcCallbackRequest) (entity.User, entity.AuthTokens, error) {
+	reqID := requestIdFromContext(ctx)
+
+	if s.sso == nil {
+		return entity.User{}, entity.AuthTokens{}, ErrOidcUnknownProvider
+	}
+
+	identity, err := s.sso.Finish(ctx, provider, flow, req.State, req.Code)
+
+	if err != nil {
+		if !errors.Is(err, ErrOidcUnknownProvider) {
+			s.logger.Warn("⚠️ OIDC login failed", zap.String("RequestId", reqID), zap.String("Provider", provider), zap.Error(err))
+		}
+
+		return entity.User{}, entity.AuthTokens{}, err
+	}
+
+	user, err := s.store.IAuth.LinkIdentity(ctx, entity.UserIdentity{
+		Provider:      identity.Provider,
+		Subject:       identity.Subject,
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+	})
+
+	if errors.Is(err, ErrIdentityEmailNotVerified) {
+		s.logger.Warn("⚠️ OIDC login with an unverified email", zap.String("RequestId", reqID), zap.String("Provider", provider), zap.String("Subject", identity.Subject))
+		return entity.User{}, entity.AuthTokens{}, err
+	}
+
+	if err != nil {
+		return entity.User{}, entity.AuthTokens{}, err
+	}
+
+	s.logger.Info("✅ OIDC login", zap.String("RequestId", reqID), zap.String("Provider", provider), zap.Uint("UserId", user.ID))
+
+	return s.auth.completeLogin(ctx, user)
+}
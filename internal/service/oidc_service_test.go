@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/interfaces"
+	"github.com/ariefzainuri96/go-logstream/internal/sso"
+	"github.com/ariefzainuri96/go-logstream/internal/sso/ssotest"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"go.uber.org/zap"
+)
+
+// fakeIdentities links identities like AuthStore.LinkIdentity: a new identity needs an
+// email the provider verified.
+type fakeIdentities struct {
+	interfaces.IAuth
+	linked *[]entity.UserIdentity
+}
+
+func (s fakeIdentities) LinkIdentity(_ context.Context, identity entity.UserIdentity) (entity.User, error) {
+	*s.linked = append(*s.linked, identity)
+
+	if !identity.EmailVerified || identity.Email == "" {
+		return entity.User{}, store.ErrIdentityEmailNotVerified
+	}
+
+	now := time.Now()
+
+	return entity.User{BaseEntity: entity.BaseEntity{ID: 1}, Email: identity.Email, EmailVerifiedAt: &now}, nil
+}
+
+func (s fakeIdentities) IssueTokens(context.Context, entity.User) (entity.AuthTokens, error) {
+	return entity.AuthTokens{AccessToken: "access-token"}, nil
+}
+
+type fakeInvites struct {
+	interfaces.IProjectMember
+}
+
+func (fakeInvites) AcceptInvites(context.Context, uint, string) (int64, error) {
+	return 0, nil
+}
+
+func TestFinishOidcLinksVerifiedEmailsOnly(t *testing.T) {
+	tests := []struct {
+		name          string
+		emailVerified any
+		wantErr       error
+	}{
+		{"verified", true, nil},
+		{"not verified", false, ErrIdentityEmailNotVerified},
+		{"not sent", nil, ErrIdentityEmailNotVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := ssotest.NewIssuer(t)
+			issuer.Claims["email"] = "a@example.com"
+
+			if tt.emailVerified != nil {
+				issuer.Claims["email_verified"] = tt.emailVerified
+			}
+
+			var linked []entity.UserIdentity
+
+			storage := store.Storage{IAuth: fakeIdentities{linked: &linked}, IProjectMember: fakeInvites{}}
+			cfg := AuthConfig{SSO: sso.New(sso.Config{
+				Secret: []byte("test-secret"),
+				Providers: []sso.ProviderConfig{{
+					Name:        "test",
+					Issuer:      issuer.URL,
+					ClientID:    "client",
+					RedirectURL: "https://logstream.example.com/v1/auth/oidc/test/callback",
+				}},
+			})}
+			s := NewService(storage, zap.NewNop(), cfg)
+
+			authURL, flow, err := s.IOidc.StartOidc(context.Background(), "test")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			code, state := issuer.Authorize(t, authURL)
+
+			_, tokens, err := s.IOidc.FinishOidc(context.Background(), "test", flow, request.OidcCallbackRequest{Code: code, State: state})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil && tokens.AccessToken != "" {
+				t.Fatal("an unverified email logged in")
+			}
+
+			if len(linked) != 1 || linked[0].Email != "a@example.com" || linked[0].Subject != "user-1" {
+				t.Fatalf("linked %+v, want the identity of the provider", linked)
+			}
+		})
+	}
+}
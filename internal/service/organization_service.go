@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrPersonalOrg is returned when changing the members of a personal org, it only
+// ever has its user.
+var ErrPersonalOrg = errors.New("members of a personal organization can't be changed")
+
+type OrganizationService struct {
+	logger *zap.Logger
+	store  store.Storage
+}
+
+func NewOrganizationService(store store.Storage, logger *zap.Logger) *OrganizationService {
+	return &OrganizationService{
+		logger: logger,
+		store:  store,
+	}
+}
+
+func (s *OrganizationService) GetOrganizations(ctx context.Context, userId uint) ([]entity.Organization, error) {
+	organizations, err := s.store.IOrganization.GetOrganizations(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+func (s *OrganizationService) GetOrganization(ctx context.Context, orgId uint) (entity.Organization, error) {
+	err := authorizeOrg(ctx, s.store, orgId, entity.ProjectRoleViewer)
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	organization, err := s.store.IOrganization.GetOrganization(ctx, orgId)
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+func (s *OrganizationService) GetPersonalOrganization(ctx context.Context, userId uint) (entity.Organization, error) {
+	organization, err := s.store.IOrganization.GetPersonalOrganization(ctx, userId)
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+func (s *OrganizationService) AddOrganization(ctx context.Context, userId uint, req request.AddOrganizationRequest) (entity.Organization, error) {
+	organization, err := s.store.IOrganization.AddOrganization(ctx, userId, req)
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	s.logger.Info("✅ Organization created", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("OrgId", organization.ID), zap.Uint("UserId", userId))
+
+	return organization, nil
+}
+
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, orgId uint, req request.AddOrganizationRequest) (entity.Organization, error) {
+	err := authorizeOrg(ctx, s.store, orgId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	organization, err := s.store.IOrganization.UpdateOrganization(ctx, orgId, req)
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+func (s *OrganizationService) GetOrgMembers(ctx context.Context, orgId uint) ([]entity.OrgMember, error) {
+	err := authorizeOrg(ctx, s.store, orgId, entity.ProjectRoleViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.store.IOrganization.GetOrgMembers(ctx, orgId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// checkSharedOrg returns ErrPersonalOrg for the personal org of a user.
+func (s *OrganizationService) checkSharedOrg(ctx context.Context, orgId uint) error {
+	organization, err := s.store.IOrganization.GetOrganization(ctx, orgId)
+
+	if err != nil {
+		return err
+	}
+
+	if organization.PersonalUserId != nil {
+		return ErrPersonalOrg
+	}
+
+	return nil
+}
+
+func (s *OrganizationService) AddOrgMember(ctx context.Context, orgId uint, req request.AddOrgMemberRequest) (entity.OrgMember, error) {
+	err := authorizeOrg(ctx, s.store, orgId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.OrgMember{}, err
+	}
+
+	err = s.checkSharedOrg(ctx, orgId)
+
+	if err != nil {
+		return entity.OrgMember{}, err
+	}
+
+	members, err := s.store.IOrganization.GetOrgMembers(ctx, orgId)
+
+	if err != nil {
+		return entity.OrgMember{}, err
+	}
+
+	for _, member := range members {
+		if strings.EqualFold(member.Email, req.Email) {
+			return entity.OrgMember{}, ErrMemberExists
+		}
+	}
+
+	member, err := s.store.IOrganization.AddOrgMember(ctx, orgId, req)
+
+	if err != nil {
+		return entity.OrgMember{}, err
+	}
+
+	s.logger.Info("✅ Organization member added", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("OrgId", orgId), zap.Uint("MemberId", member.ID))
+
+	return member, nil
+}
+
+// checkLastOrgOwner returns ErrLastOwner when memberId is the only owner of the org,
+// gorm.ErrRecordNotFound when it isn't a member of the org.
+func (s *OrganizationService) checkLastOrgOwner(ctx context.Context, orgId uint, memberId uint) error {
+	members, err := s.store.IOrganization.GetOrgMembers(ctx, orgId)
+
+	if err != nil {
+		return err
+	}
+
+	var target *entity.OrgMember
+	owners := 0
+
+	for i, member := range members {
+		if member.ID == memberId {
+			target = &members[i]
+		}
+
+		if member.Role == entity.ProjectRoleOwner {
+			owners++
+		}
+	}
+
+	if target == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	if target.Role == entity.ProjectRoleOwner && owners == 1 {
+		return ErrLastOwner
+	}
+
+	return nil
+}
+
+func (s *OrganizationService) UpdateOrgMember(ctx context.Context, orgId uint, memberId uint, req request.UpdateOrgMemberRequest) (entity.OrgMember, error) {
+	err := authorizeOrg(ctx, s.store, orgId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.OrgMember{}, err
+	}
+
+	if req.Role != entity.ProjectRoleOwner {
+		err = s.checkLastOrgOwner(ctx, orgId, memberId)
+
+		if err != nil {
+			return entity.OrgMember{}, err
+		}
+	}
+
+	member, err := s.store.IOrganization.UpdateOrgMember(ctx, orgId, memberId, req)
+
+	if err != nil {
+		return entity.OrgMember{}, err
+	}
+
+	return member, nil
+}
+
+func (s *OrganizationService) DeleteOrgMember(ctx context.Context, orgId uint, memberId uint) error {
+	err := authorizeOrg(ctx, s.store, orgId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.checkLastOrgOwner(ctx, orgId, memberId)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.store.IOrganization.DeleteOrgMember(ctx, orgId, memberId)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetOrgMemberRole resolves the role of a user for middleware.OrgRoleHandler, it is
+// the authorization check itself so it isn't authorized.
+func (s *OrganizationService) GetOrgMemberRole(ctx context.Context, orgId uint, userId uint) (string, error) {
+	role, err := s.store.IOrganization.GetOrgMemberRole(ctx, orgId, userId)
+
+	if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
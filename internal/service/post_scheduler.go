@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"go.uber.org/zap"
+)
+
+type PostSchedulerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+type PostScheduler struct {
+	logger *zap.Logger
+	store  store.Storage
+	cfg    PostSchedulerConfig
+}
+
+func NewPostScheduler(store store.Storage, logger *zap.Logger, cfg PostSchedulerConfig) *PostScheduler {
+	return &PostScheduler{
+		logger: logger,
+		store:  store,
+		cfg:    cfg,
+	}
+}
+
+// Run publishes due drafts until ctx is canceled.
+func (s *PostScheduler) Run(ctx context.Context) error {
+	s.logger.Info("starting post scheduler", zap.Duration("PollInterval", s.cfg.PollInterval))
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.publishDuePosts(ctx)
+		}
+	}
+}
+
+func (s *PostScheduler) publishDuePosts(ctx context.Context) {
+	// drain every due post, a deploy can schedule more than one batch at once
+	for {
+		posts, err := s.store.IPostScheduler.PublishDuePosts(ctx, s.cfg.BatchSize, s.publishedDeliveries)
+
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Error("⚠️ Failed to publish scheduled posts", zap.Error(err))
+			}
+			return
+		}
+
+		for _, post := range posts {
+			s.logger.Info("✅ Scheduled post published", zap.Uint("PostId", post.ID), zap.Uint("ProjectId", post.ProjectId), zap.Timep("PublishAt", post.PublishAt))
+		}
+
+		if len(posts) < s.cfg.BatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// publishedDeliveries builds the post.published deliveries of post to webhooks, a
+// webhook whose payload can't be built is skipped and doesn't hold the publish back.
+func (s *PostScheduler) publishedDeliveries(post entity.Post, webhooks []entity.ProjectWebhook) []entity.WebhookDelivery {
+	var deliveries []entity.WebhookDelivery
+
+	for _, hook := range webhooks {
+		delivery, err := newWebhookDelivery(hook, post, entity.WebhookEventPostPublished)
+
+		if err != nil {
+			s.logger.Error("⚠️ Failed to build webhook payload", zap.String("RequestId", "post-scheduler"), zap.Uint("PostId", post.ID), zap.Uint("WebhookId", hook.ID), zap.Error(err))
+			continue
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries
+}
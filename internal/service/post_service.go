@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
@@ -15,11 +16,12 @@ import (
 	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
 	"github.com/ariefzainuri96/go-logstream/internal/store"
 	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/webhook"
+	"github.com/ariefzainuri96/go-logstream/pkg/webhooksig"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
-type WebhookPayload map[string]interface{}
-
 type PostService struct {
 	logger *zap.Logger
 	store  store.Storage
@@ -32,135 +34,204 @@ func NewPostService(store store.Storage, logger *zap.Logger) *PostService {
 	}
 }
 
-func (s *PostService) CreatePost(ctx context.Context, req request.AddPostRequest) (entity.Post, error) {
+// ErrInvalidPostTransition is returned when publishing a published post or
+// unpublishing a draft.
+var ErrInvalidPostTransition = errors.New("invalid post status transition")
+
+func requestIdFromContext(ctx context.Context) string {
 	reqID, ok := ctx.Value(middleware.CtxRequestID).(string)
 
-	// 2. Safety check: Context values are optional!
+	// Safety check: Context values are optional!
 	if !ok {
 		reqID = "unknown-request" // Fallback if missing
 	}
 
+	return reqID
+}
+
+func (s *PostService) CreatePost(ctx context.Context, req request.AddPostRequest) (entity.Post, error) {
+	reqID := requestIdFromContext(ctx)
+
+	_, err := authorizeProject(ctx, s.store, req.ProjectId, entity.ProjectRoleEditor)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
 	post, err := s.store.IPost.CreatePost(ctx, req)
 
 	if err != nil {
 		return entity.Post{}, err
 	}
 
-	if post.Project.WebhookUrl != "" {
-		go func(p entity.Post) {
-			err := callWebhook(p)
+	s.notifyWebhooks(ctx, reqID, post, entity.WebhookEventPostCreated)
 
-			if err != nil {
-				s.logger.Error("⚠️ Failed to trigger webhook", zap.String("RequestId", reqID), zap.Uint("PostId", p.ID), zap.Error(err))
-			} else {
-				s.logger.Info("✅ Webhook triggered successfully", zap.String("RequestId", reqID), zap.Uint("PostId", p.ID))
-			}
-		}(post)
+	if post.Status == "published" {
+		s.notifyWebhooks(ctx, reqID, post, entity.WebhookEventPostPublished)
 	}
 
 	return post, nil
 }
 
-func callWebhook(post entity.Post) error {
-	// 1. Create a client with a timeout (CRITICAL for stability)
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+// notifyWebhooks fans event out to every enabled webhook of the post's project
+// subscribed to it.
+func (s *PostService) notifyWebhooks(ctx context.Context, reqID string, post entity.Post, event string) {
+	webhooks, err := s.store.IProjectWebhook.GetSubscribedWebhooks(ctx, post.ProjectId, event)
+
+	if err != nil {
+		s.logger.Error("⚠️ Failed to get project webhooks", zap.String("RequestId", reqID), zap.Uint("PostId", post.ID), zap.String("Event", event), zap.Error(err))
+		return
 	}
 
-	// 2. Prepare payload (if doing POST)
-	jsonPayload, _ := json.Marshal(webhookPayload(post))
+	for _, hook := range webhooks {
+		s.enqueueWebhook(ctx, reqID, hook, post, event)
+	}
+}
 
-	// 3. Create the Custom Request (POST example)
-	req, err := http.NewRequest("POST", post.Project.WebhookUrl, bytes.NewBuffer(jsonPayload))
+// enqueueWebhook persists the notification so the webhook worker can deliver
+// (and retry) it, instead of firing a request that is lost on failure or restart.
+func (s *PostService) enqueueWebhook(ctx context.Context, reqID string, hook entity.ProjectWebhook, post entity.Post, event string) {
+	delivery, err := newWebhookDelivery(hook, post, event)
 
 	if err != nil {
-		return err
+		s.logger.Error("⚠️ Failed to build webhook payload", zap.String("RequestId", reqID), zap.Uint("PostId", post.ID), zap.Uint("WebhookId", hook.ID), zap.Error(err))
+		return
+	}
+
+	delivery, err = s.store.IWebhookQueue.EnqueueDelivery(ctx, delivery)
+
+	if err != nil {
+		s.logger.Error("⚠️ Failed to enqueue webhook", zap.String("RequestId", reqID), zap.Uint("PostId", post.ID), zap.Uint("WebhookId", hook.ID), zap.Error(err))
+		return
+	}
+
+	s.logger.Info("✅ Webhook enqueued", zap.String("RequestId", reqID), zap.Uint("PostId", post.ID), zap.Uint("WebhookId", hook.ID), zap.Uint("DeliveryId", delivery.ID))
+}
+
+// newWebhookDelivery is the delivery notifying hook of event on post.
+func newWebhookDelivery(hook entity.ProjectWebhook, post entity.Post, event string) (entity.WebhookDelivery, error) {
+	payload, err := webhookPayload(hook, event, post)
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	webhookId := hook.ID
+
+	delivery := entity.WebhookDelivery{
+		ProjectId: post.ProjectId,
+		WebhookId: &webhookId,
+		Event:     event,
+		Provider:  hook.Provider,
+		Url:       hook.Url,
+		Payload:   string(payload),
+	}
+
+	// a deleted post can't be referenced anymore, the payload still carries it
+	if event != entity.WebhookEventPostDeleted {
+		postId := post.ID
+		delivery.PostId = &postId
+	}
+
+	return delivery, nil
+}
+
+// webhookPayload renders the webhook's payload template when it has one, otherwise
+// the payload of its provider.
+func webhookPayload(w entity.ProjectWebhook, event string, post entity.Post) ([]byte, error) {
+	msg := webhook.Message{
+		Event: event,
+		Post:  post,
+		Url:   w.Url,
 	}
 
-	// 4. Set Headers (like Auth or Content-Type)
+	if w.PayloadTemplate != "" {
+		return webhook.RenderTemplate(w.PayloadTemplate, msg)
+	}
+
+	payload, err := webhook.Format(w.Provider, msg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(payload)
+}
+
+// response bodies kept in the delivery log are truncated to this size
+const webhookResponseSnippetSize = 2048
+
+// sends every webhook, it refuses addresses that aren't public
+var webhookClient = webhook.NewClient(10 * time.Second)
+
+type WebhookResult struct {
+	StatusCode int
+	Body       string
+	Latency    time.Duration
+}
+
+// callWebhook posts payload to url. When the project has a signing secret, the request
+// carries X-LogStream-Timestamp and X-LogStream-Signature headers (see pkg/webhooksig).
+func callWebhook(ctx context.Context, url string, payload []byte, secret string) (WebhookResult, error) {
+	var result WebhookResult
+
+	// 1. The client has a timeout (CRITICAL for stability) and refuses internal addresses
+	client := webhookClient
+
+	// 2. Create the Custom Request (POST example)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
+
+	if err != nil {
+		return result, err
+	}
+
+	// 3. Set Headers (like Auth or Content-Type)
 	req.Header.Set("Content-Type", "application/json")
 
-	// 5. Execute the request
+	if secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(webhooksig.TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(webhooksig.SignatureHeader, webhooksig.Sign(secret, timestamp, payload))
+	}
+
+	// 4. Execute the request
+	start := time.Now()
 	resp, err := client.Do(req)
+	result.Latency = time.Since(start)
+
 	if err != nil {
-		return err
+		return result, err
 	}
 
-	// 2. IMPORTANT: Close the body when done to prevent memory leaks
+	// 5. IMPORTANT: Close the body when done to prevent memory leaks
 	defer resp.Body.Close()
 
-	// 3. Read the body
-	_, err = io.ReadAll(resp.Body)
+	result.StatusCode = resp.StatusCode
+
+	// 6. Read the body (only the part we keep in the delivery log)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, webhookResponseSnippetSize))
 
 	if err != nil {
-		return err
+		return result, err
 	}
 
-	if resp.StatusCode != 200 {
-		return errors.New("Return code not 200")
+	result.Body = string(body)
+
+	// Discord answers 204 No Content, so accept any 2xx
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, fmt.Errorf("webhook returned status %d", resp.StatusCode)
 	}
 
-	return nil
+	return result, nil
 }
 
-func webhookPayload(post entity.Post) map[string]interface{} {
-	switch post.Project.WebhookProvider {
-	case "discord":
-		// Discord expects "content" or "embeds"
-		return WebhookPayload{
-			"username": "LogStream", // Custom bot name
-			"embeds": []map[string]interface{}{
-				{
-					"title":       post.Title,
-					"description": post.Content, // Or a snippet if it's too long
-					"color":       5814783,      // A nice blue color (Decimal value)
-					"fields": []map[string]interface{}{
-						{
-							"name":   "Category",
-							"value":  post.Category,
-							"inline": true,
-						},
-						{
-							"name":   "Status",
-							"value":  post.Status,
-							"inline": true,
-						},
-						{
-							"name":   "Post ID",
-							"value":  fmt.Sprintf("%d", post.ID),
-							"inline": true,
-						},
-					},
-					"footer": map[string]string{
-						"text": "Sent via LogStream",
-					},
-					"timestamp": time.Now().Format(time.RFC3339),
-				},
-			},
-		}
-
-	case "slack":
-		// Slack expects "text"
-		return WebhookPayload{
-			"text": fmt.Sprintf("*New Update: %s*\nCategory: %s\n<%s>", post.Title, post.Category, post.Content),
-		}
-
-	default: // "generic"
-		// Send the raw data for custom integrations (Zapier, n8n, custom backends)
-		return WebhookPayload{
-			"event": "post_created",
-			"data": map[string]interface{}{
-				"id":         post.ID,
-				"title":      post.Title,
-				"content":    post.Content,
-				"category":   post.Category,
-				"created_at": post.CreatedAt,
-			},
-		}
+func (s *PostService) GetPost(ctx context.Context, req request.GetPostRequest) (utils.PaginateResult[entity.Post], error) {
+	_, err := authorizeProject(ctx, s.store, req.ProjectId, entity.ProjectRoleViewer)
+
+	if err != nil {
+		return utils.PaginateResult[entity.Post]{}, err
 	}
-}
 
-func (s *PostService) GetPost(ctx context.Context, req request.GetPostRequest) (utils.PaginateResult[entity.Post], error) {
 	post, err := s.store.IPost.GetPost(ctx, req)
 
 	if err != nil {
@@ -169,3 +240,108 @@ func (s *PostService) GetPost(ctx context.Context, req request.GetPostRequest) (
 
 	return post, nil
 }
+
+func (s *PostService) GetPostById(ctx context.Context, postId uint) (entity.Post, error) {
+	post, err := authorizePost(ctx, s.store, postId, entity.ProjectRoleViewer)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	return post, nil
+}
+
+func (s *PostService) UpdatePost(ctx context.Context, postId uint, req request.UpdatePostRequest) (entity.Post, error) {
+	_, err := authorizePost(ctx, s.store, postId, entity.ProjectRoleEditor)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	post, err := s.store.IPost.UpdatePost(ctx, postId, req)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	s.notifyWebhooks(ctx, requestIdFromContext(ctx), post, entity.WebhookEventPostUpdated)
+
+	return post, nil
+}
+
+func (s *PostService) PatchPost(ctx context.Context, postId uint, req request.PatchPostRequest) (entity.Post, error) {
+	_, err := authorizePost(ctx, s.store, postId, entity.ProjectRoleEditor)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	post, err := s.store.IPost.PatchPost(ctx, postId, req)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	// an empty patch changes nothing, so there is nothing to notify
+	if len(req.Updates()) > 0 {
+		s.notifyWebhooks(ctx, requestIdFromContext(ctx), post, entity.WebhookEventPostUpdated)
+	}
+
+	return post, nil
+}
+
+func (s *PostService) DeletePost(ctx context.Context, postId uint) error {
+	// keep the post for the webhook payload
+	post, err := authorizePost(ctx, s.store, postId, entity.ProjectRoleEditor)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.store.IPost.DeletePost(ctx, postId)
+
+	if err != nil {
+		return err
+	}
+
+	s.notifyWebhooks(ctx, requestIdFromContext(ctx), post, entity.WebhookEventPostDeleted)
+
+	return nil
+}
+
+func (s *PostService) PublishPost(ctx context.Context, postId uint) (entity.Post, error) {
+	return s.transitionPost(ctx, postId, "published", s.store.IPost.PublishPost, entity.WebhookEventPostPublished)
+}
+
+func (s *PostService) UnpublishPost(ctx context.Context, postId uint) (entity.Post, error) {
+	return s.transitionPost(ctx, postId, "draft", s.store.IPost.UnpublishPost, entity.WebhookEventPostUnpublished)
+}
+
+// transitionPost moves a post to status with transition and fires event, moving a post
+// to the status it already has returns ErrInvalidPostTransition.
+func (s *PostService) transitionPost(ctx context.Context, postId uint, status string, transition func(context.Context, uint) (entity.Post, error), event string) (entity.Post, error) {
+	current, err := authorizePost(ctx, s.store, postId, entity.ProjectRoleEditor)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	if current.Status == status {
+		return entity.Post{}, fmt.Errorf("%w: post is already %s", ErrInvalidPostTransition, status)
+	}
+
+	post, err := transition(ctx, postId)
+
+	// the post exists, so nothing matched because a concurrent request moved it first
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.Post{}, fmt.Errorf("%w: post is already %s", ErrInvalidPostTransition, status)
+	}
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	s.notifyWebhooks(ctx, requestIdFromContext(ctx), post, event)
+
+	return post, nil
+}
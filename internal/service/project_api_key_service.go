@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"go.uber.org/zap"
+)
+
+type ProjectApiKeyService struct {
+	logger *zap.Logger
+	store  store.Storage
+}
+
+func NewProjectApiKeyService(store store.Storage, logger *zap.Logger) *ProjectApiKeyService {
+	return &ProjectApiKeyService{
+		logger: logger,
+		store:  store,
+	}
+}
+
+func (s *ProjectApiKeyService) GetApiKeys(ctx context.Context, projectId uint) ([]entity.ProjectApiKey, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.IProjectApiKey.GetApiKeys(ctx, projectId)
+}
+
+func (s *ProjectApiKeyService) AddApiKey(ctx context.Context, projectId uint, userId uint, req request.AddProjectApiKeyRequest) (entity.ProjectApiKey, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.ProjectApiKey{}, err
+	}
+
+	apiKey, err := s.store.IProjectApiKey.AddApiKey(ctx, projectId, userId, req)
+
+	if err != nil {
+		return entity.ProjectApiKey{}, err
+	}
+
+	s.logger.Info("✅ Project api key created", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("ProjectId", projectId), zap.Uint("ApiKeyId", apiKey.ID), zap.String("Prefix", apiKey.Prefix))
+
+	return apiKey, nil
+}
+
+func (s *ProjectApiKeyService) RevokeApiKey(ctx context.Context, projectId uint, keyId uint) error {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.store.IProjectApiKey.RevokeApiKey(ctx, projectId, keyId)
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("✅ Project api key revoked", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("ProjectId", projectId), zap.Uint("ApiKeyId", keyId))
+
+	return nil
+}
+
+// AuthenticateApiKey is used by the Authentication middleware, before any caller is
+// known.
+func (s *ProjectApiKeyService) AuthenticateApiKey(ctx context.Context, key string) (entity.ProjectApiKey, error) {
+	return s.store.IProjectApiKey.AuthenticateApiKey(ctx, key)
+}
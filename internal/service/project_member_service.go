@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMemberExists = errors.New("email is already a member of the project")
+	// a project always keeps an owner to manage it
+	ErrLastOwner = errors.New("the last owner of a project can't be removed or demoted")
+)
+
+type ProjectMemberService struct {
+	logger *zap.Logger
+	store  store.Storage
+}
+
+func NewProjectMemberService(store store.Storage, logger *zap.Logger) *ProjectMemberService {
+	return &ProjectMemberService{
+		logger: logger,
+		store:  store,
+	}
+}
+
+func (s *ProjectMemberService) GetMembers(ctx context.Context, projectId uint) ([]entity.ProjectMember, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.store.IProjectMember.GetMembers(ctx, projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s *ProjectMemberService) AddMember(ctx context.Context, projectId uint, req request.AddProjectMemberRequest) (entity.ProjectMember, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.ProjectMember{}, err
+	}
+
+	members, err := s.store.IProjectMember.GetMembers(ctx, projectId)
+
+	if err != nil {
+		return entity.ProjectMember{}, err
+	}
+
+	for _, member := range members {
+		if strings.EqualFold(member.Email, req.Email) {
+			return entity.ProjectMember{}, ErrMemberExists
+		}
+	}
+
+	member, err := s.store.IProjectMember.AddMember(ctx, projectId, req)
+
+	if err != nil {
+		return entity.ProjectMember{}, err
+	}
+
+	s.logger.Info("✅ Project member invited", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("ProjectId", projectId), zap.Uint("MemberId", member.ID), zap.Bool("Pending", member.UserId == nil))
+
+	return member, nil
+}
+
+// checkLastOwner returns ErrLastOwner when memberId is the only owner of the project,
+// gorm.ErrRecordNotFound when it isn't a member of the project.
+func (s *ProjectMemberService) checkLastOwner(ctx context.Context, projectId uint, memberId uint) error {
+	members, err := s.store.IProjectMember.GetMembers(ctx, projectId)
+
+	if err != nil {
+		return err
+	}
+
+	var target *entity.ProjectMember
+	owners := 0
+
+	for i, member := range members {
+		if member.ID == memberId {
+			target = &members[i]
+		}
+
+		// pending owners can't manage the project yet
+		if member.Role == entity.ProjectRoleOwner && member.UserId != nil {
+			owners++
+		}
+	}
+
+	if target == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	if target.Role == entity.ProjectRoleOwner && target.UserId != nil && owners == 1 {
+		return ErrLastOwner
+	}
+
+	return nil
+}
+
+func (s *ProjectMemberService) UpdateMember(ctx context.Context, projectId uint, memberId uint, req request.UpdateProjectMemberRequest) (entity.ProjectMember, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.ProjectMember{}, err
+	}
+
+	if req.Role != entity.ProjectRoleOwner {
+		err = s.checkLastOwner(ctx, projectId, memberId)
+
+		if err != nil {
+			return entity.ProjectMember{}, err
+		}
+	}
+
+	member, err := s.store.IProjectMember.UpdateMember(ctx, projectId, memberId, req)
+
+	if err != nil {
+		return entity.ProjectMember{}, err
+	}
+
+	return member, nil
+}
+
+func (s *ProjectMemberService) DeleteMember(ctx context.Context, projectId uint, memberId uint) error {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.checkLastOwner(ctx, projectId, memberId)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.store.IProjectMember.DeleteMember(ctx, projectId, memberId)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetMemberRole resolves the role of a user for middleware.ProjectRoleHandler, it is
+// the authorization check itself so it isn't authorized.
+func (s *ProjectMemberService) GetMemberRole(ctx context.Context, projectId uint, userId uint) (string, error) {
+	role, err := s.store.IProjectMember.GetMemberRole(ctx, projectId, userId)
+
+	if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (s *ProjectMemberService) AcceptInvites(ctx context.Context, userId uint, email string) (int64, error) {
+	joined, err := s.store.IProjectMember.AcceptInvites(ctx, userId, email)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if joined > 0 {
+		s.logger.Info("✅ Project invitations accepted", zap.String("RequestId", requestIdFromContext(ctx)), zap.Uint("UserId", userId), zap.Int64("Projects", joined))
+	}
+
+	return joined, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
 	"github.com/ariefzainuri96/go-logstream/internal/store"
 	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"github.com/ariefzainuri96/go-logstream/internal/webhook"
 	"go.uber.org/zap"
 )
 
@@ -32,7 +33,33 @@ func (s *ProjectService) CheckSlug(ctx context.Context, req request.CheckSlugReq
 	return slugExist, nil
 }
 
+// AddProject creates a project in req.OrgId, where the user must be at least an editor,
+// or in the personal org of the user.
 func (s *ProjectService) AddProject(ctx context.Context, userId uint, req request.AddProjectRequest) (entity.Project, error) {
+	if req.OrgId == 0 {
+		organization, err := s.store.IOrganization.GetPersonalOrganization(ctx, userId)
+
+		if err != nil {
+			return entity.Project{}, err
+		}
+
+		req.OrgId = organization.ID
+	} else {
+		err := authorizeOrg(ctx, s.store, req.OrgId, entity.ProjectRoleEditor)
+
+		if err != nil {
+			return entity.Project{}, err
+		}
+	}
+
+	if req.WebhookUrl != "" {
+		err := webhook.ValidateUrl(req.WebhookProvider, req.WebhookUrl)
+
+		if err != nil {
+			return entity.Project{}, err
+		}
+	}
+
 	project, err := s.store.IProject.AddProject(ctx, userId, req)
 
 	if err != nil {
@@ -52,8 +79,24 @@ func (s *ProjectService) GetProject(ctx context.Context, userId uint, req reques
 	return result, nil
 }
 
+func (s *ProjectService) GetProjectById(ctx context.Context, projectId uint) (entity.Project, error) {
+	project, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleViewer)
+
+	if err != nil {
+		return entity.Project{}, err
+	}
+
+	return project, nil
+}
+
 func (s *ProjectService) DeleteProject(ctx context.Context, projectId uint) error {
-	err := s.store.IProject.DeleteProject(ctx, projectId)
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.store.IProject.DeleteProject(ctx, projectId)
 
 	if err != nil {
 		return err
@@ -63,6 +106,12 @@ func (s *ProjectService) DeleteProject(ctx context.Context, projectId uint) erro
 }
 
 func (s *ProjectService) UpdateProject(ctx context.Context, projectId uint, req request.AddProjectRequest) (entity.Project, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.Project{}, err
+	}
+
 	project, err := s.store.IProject.UpdateProject(ctx, projectId, req)
 
 	if err != nil {
@@ -71,3 +120,37 @@ func (s *ProjectService) UpdateProject(ctx context.Context, projectId uint, req
 
 	return project, nil
 }
+
+func (s *ProjectService) GetWebhookSecret(ctx context.Context, projectId uint) (string, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := s.store.IProject.GetWebhookSecret(ctx, projectId)
+
+	if err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+func (s *ProjectService) RotateWebhookSecret(ctx context.Context, projectId uint) (string, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := s.store.IProject.RotateWebhookSecret(ctx, projectId)
+
+	if err != nil {
+		return "", err
+	}
+
+	s.logger.Info("✅ Webhook secret rotated", zap.Uint("ProjectId", projectId))
+
+	return secret, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"github.com/ariefzainuri96/go-logstream/internal/webhook"
+	"go.uber.org/zap"
+)
+
+type ProjectWebhookService struct {
+	logger *zap.Logger
+	store  store.Storage
+}
+
+func NewProjectWebhookService(store store.Storage, logger *zap.Logger) *ProjectWebhookService {
+	return &ProjectWebhookService{
+		logger: logger,
+		store:  store,
+	}
+}
+
+func (s *ProjectWebhookService) GetWebhooks(ctx context.Context, projectId uint) ([]entity.ProjectWebhook, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.store.IProjectWebhook.GetWebhooks(ctx, projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (s *ProjectWebhookService) GetWebhook(ctx context.Context, projectId uint, webhookId uint) (entity.ProjectWebhook, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	webhook, err := s.store.IProjectWebhook.GetWebhook(ctx, projectId, webhookId)
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (s *ProjectWebhookService) AddWebhook(ctx context.Context, projectId uint, req request.AddProjectWebhookRequest) (entity.ProjectWebhook, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	err = webhook.ValidateUrl(req.Provider, req.Url)
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	hook, err := s.store.IProjectWebhook.AddWebhook(ctx, projectId, req)
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	return hook, nil
+}
+
+func (s *ProjectWebhookService) UpdateWebhook(ctx context.Context, projectId uint, webhookId uint, req request.AddProjectWebhookRequest) (entity.ProjectWebhook, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	err = webhook.ValidateUrl(req.Provider, req.Url)
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	hook, err := s.store.IProjectWebhook.UpdateWebhook(ctx, projectId, webhookId, req)
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	return hook, nil
+}
+
+func (s *ProjectWebhookService) DeleteWebhook(ctx context.Context, projectId uint, webhookId uint) error {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.store.IProjectWebhook.DeleteWebhook(ctx, projectId, webhookId)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ProjectWebhookService) GetSubscribedWebhooks(ctx context.Context, projectId uint, event string) ([]entity.ProjectWebhook, error) {
+	webhooks, err := s.store.IProjectWebhook.GetSubscribedWebhooks(ctx, projectId, event)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"go.uber.org/zap"
+)
+
+type PublicService struct {
+	logger *zap.Logger
+	store  store.Storage
+}
+
+func NewPublicService(store store.Storage, logger *zap.Logger) *PublicService {
+	return &PublicService{
+		logger: logger,
+		store:  store,
+	}
+}
+
+func (s *PublicService) GetPublicProject(ctx context.Context, slug string) (entity.Project, error) {
+	project, err := s.store.IPublic.GetPublicProject(ctx, slug)
+
+	if err != nil {
+		return entity.Project{}, err
+	}
+
+	return project, nil
+}
+
+func (s *PublicService) GetPublicPosts(ctx context.Context, projectId uint, req request.GetPublicPostRequest) (utils.PaginateResult[entity.Post], error) {
+	posts, err := s.store.IPublic.GetPublicPosts(ctx, projectId, req)
+
+	if err != nil {
+		return utils.PaginateResult[entity.Post]{}, err
+	}
+
+	return posts, nil
+}
+
+func (s *PublicService) CountPublicPostsSince(ctx context.Context, projectId uint, since time.Time) (int64, error) {
+	count, err := s.store.IPublic.CountPublicPostsSince(ctx, projectId, since)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *PublicService) GetPublicPost(ctx context.Context, projectId uint, postId uint) (entity.Post, error) {
+	post, err := s.store.IPublic.GetPublicPost(ctx, projectId, postId)
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	return post, nil
+}
+
+func (s *PublicService) GetPublicCategories(ctx context.Context, projectId uint) ([]string, error) {
+	categories, err := s.store.IPublic.GetPublicCategories(ctx, projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
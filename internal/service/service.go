@@ -7,15 +7,35 @@ import (
 )
 
 type Service struct {
-	IAuth    interfaces.IAuth
-	IProject interfaces.IProject
-	IPost    interfaces.IPost
+	IAuth            interfaces.IAuth
+	ILogin           interfaces.ILogin
+	IProject         interfaces.IProject
+	IPost            interfaces.IPost
+	IWebhookDelivery interfaces.IWebhookDelivery
+	IProjectWebhook  interfaces.IProjectWebhook
+	IWebhookTester   interfaces.IWebhookTester
+	IPublic          interfaces.IPublic
+	IProjectMember   interfaces.IProjectMember
+	IOrganization    interfaces.IOrganization
+	IProjectApiKey   interfaces.IProjectApiKey
+	IOidc            interfaces.IOidc
 }
 
-func NewService(store store.Storage, logger *zap.Logger) Service {
+func NewService(store store.Storage, logger *zap.Logger, authCfg AuthConfig) Service {
+	auth := NewAuthService(store, logger, authCfg)
+
 	return Service{
-		IAuth:    NewAuthService(store, logger),
-		IProject: NewProjectService(store, logger),
-		IPost: NewPostService(store, logger),
+		IAuth:            auth,
+		ILogin:           auth,
+		IProject:         NewProjectService(store, logger),
+		IPost:            NewPostService(store, logger),
+		IWebhookDelivery: NewWebhookDeliveryService(store, logger),
+		IProjectWebhook:  NewProjectWebhookService(store, logger),
+		IWebhookTester:   NewWebhookTesterService(store, logger),
+		IPublic:          NewPublicService(store, logger),
+		IProjectMember:   NewProjectMemberService(store, logger),
+		IOrganization:    NewOrganizationService(store, logger),
+		IProjectApiKey:   NewProjectApiKeyService(store, logger),
+		IOidc:            NewOidcService(store, logger, auth, authCfg.SSO),
 	}
 }
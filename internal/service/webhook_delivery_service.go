@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrWebhookGone     = store.ErrWebhookGone
+	ErrWebhookDisabled = store.ErrWebhookDisabled
+)
+
+type WebhookDeliveryService struct {
+	logger *zap.Logger
+	store  store.Storage
+}
+
+func NewWebhookDeliveryService(store store.Storage, logger *zap.Logger) *WebhookDeliveryService {
+	return &WebhookDeliveryService{
+		logger: logger,
+		store:  store,
+	}
+}
+
+func (s *WebhookDeliveryService) GetDeliveries(ctx context.Context, projectId uint, req request.GetWebhookDeliveryRequest) (utils.PaginateResult[entity.WebhookDelivery], error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return utils.PaginateResult[entity.WebhookDelivery]{}, err
+	}
+
+	result, err := s.store.IWebhookDelivery.GetDeliveries(ctx, projectId, req)
+
+	if err != nil {
+		return utils.PaginateResult[entity.WebhookDelivery]{}, err
+	}
+
+	return result, nil
+}
+
+func (s *WebhookDeliveryService) GetDelivery(ctx context.Context, projectId uint, deliveryId uint) (entity.WebhookDelivery, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	delivery, err := s.store.IWebhookDelivery.GetDelivery(ctx, projectId, deliveryId)
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (s *WebhookDeliveryService) RedeliverDelivery(ctx context.Context, projectId uint, deliveryId uint) (entity.WebhookDelivery, error) {
+	_, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	delivery, err := s.store.IWebhookDelivery.RedeliverDelivery(ctx, projectId, deliveryId)
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	s.logger.Info("✅ Webhook redelivery enqueued", zap.Uint("ProjectId", projectId), zap.Uint("OriginalDeliveryId", deliveryId), zap.Uint("DeliveryId", delivery.ID))
+
+	return delivery, nil
+}
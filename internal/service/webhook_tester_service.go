@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/response"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"github.com/ariefzainuri96/go-logstream/internal/webhook"
+	"go.uber.org/zap"
+)
+
+type WebhookTesterService struct {
+	logger *zap.Logger
+	store  store.Storage
+}
+
+func NewWebhookTesterService(store store.Storage, logger *zap.Logger) *WebhookTesterService {
+	return &WebhookTesterService{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// samplePost is the synthetic post used to preview and test webhooks of project.
+func samplePost(project entity.Project) entity.Post {
+	return entity.Post{
+		UpdateEntity: entity.UpdateEntity{
+			BaseEntity: entity.BaseEntity{
+				CreatedAt: time.Now(),
+			},
+		},
+		ProjectId: project.ID,
+		Project:   project,
+		Title:     "Sample post from LogStream",
+		Content:   "This is a sample post used to check your webhook integration.",
+		Category:  "feature",
+		Status:    "published",
+	}
+}
+
+func (s *WebhookTesterService) PreviewTemplate(ctx context.Context, projectId uint, req request.PreviewWebhookTemplateRequest) (string, error) {
+	project, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return "", err
+	}
+
+	event := req.Event
+
+	if event == "" {
+		event = entity.WebhookEventPostCreated
+	}
+
+	payload, err := webhook.RenderTemplate(req.PayloadTemplate, webhook.Message{
+		Event: event,
+		Post:  samplePost(project),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
+// TestWebhook sends a sample post synchronously, without going through the delivery
+// queue, and reports what the receiver answered.
+func (s *WebhookTesterService) TestWebhook(ctx context.Context, projectId uint, req request.TestWebhookRequest) (response.WebhookTestResult, error) {
+	project, err := authorizeProject(ctx, s.store, projectId, entity.ProjectRoleOwner)
+
+	if err != nil {
+		return response.WebhookTestResult{}, err
+	}
+
+	hook := entity.ProjectWebhook{
+		ProjectId:       projectId,
+		Url:             req.Url,
+		Provider:        req.Provider,
+		PayloadTemplate: req.PayloadTemplate,
+	}
+
+	if req.WebhookId != 0 {
+		hook, err = s.store.IProjectWebhook.GetWebhook(ctx, projectId, req.WebhookId)
+
+		if err != nil {
+			return response.WebhookTestResult{}, err
+		}
+	}
+
+	event := req.Event
+
+	if event == "" {
+		event = entity.WebhookEventPostCreated
+	}
+
+	payload, err := webhookPayload(hook, event, samplePost(project))
+
+	if err != nil {
+		return response.WebhookTestResult{}, err
+	}
+
+	result, err := callWebhook(ctx, hook.Url, payload, project.WebhookSecret)
+
+	if errors.Is(err, webhook.ErrForbiddenAddress) {
+		return response.WebhookTestResult{}, webhook.ErrForbiddenAddress
+	}
+
+	testResult := response.WebhookTestResult{
+		Success:    err == nil,
+		Url:        hook.Url,
+		Payload:    string(payload),
+		StatusCode: result.StatusCode,
+		LatencyMs:  result.Latency.Milliseconds(),
+		Body:       result.Body,
+	}
+
+	if err != nil {
+		testResult.Error = err.Error()
+	}
+
+	s.logger.Info("Webhook test fired", zap.Uint("ProjectId", projectId), zap.Uint("WebhookId", hook.ID), zap.Int("StatusCode", result.StatusCode), zap.Bool("Success", testResult.Success))
+
+	return testResult, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"go.uber.org/zap"
+)
+
+type WebhookWorkerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// a claimed delivery not finished after this long is picked up again
+	StaleAfter time.Duration
+}
+
+type WebhookWorker struct {
+	logger *zap.Logger
+	store  store.Storage
+	cfg    WebhookWorkerConfig
+}
+
+func NewWebhookWorker(store store.Storage, logger *zap.Logger, cfg WebhookWorkerConfig) *WebhookWorker {
+	return &WebhookWorker{
+		logger: logger,
+		store:  store,
+		cfg:    cfg,
+	}
+}
+
+// Run polls the webhook_deliveries table until ctx is canceled. Deliveries that are
+// already being sent when ctx is canceled are allowed to finish, so the worker can be
+// drained by the graceful shutdown WaitGroup in main.go.
+func (w *WebhookWorker) Run(ctx context.Context) error {
+	w.logger.Info("starting webhook worker", zap.Duration("PollInterval", w.cfg.PollInterval), zap.Int("MaxAttempts", w.cfg.MaxAttempts))
+
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.processBatch(ctx)
+		}
+	}
+}
+
+func (w *WebhookWorker) processBatch(ctx context.Context) {
+	deliveries, err := w.store.IWebhookQueue.ClaimDueDeliveries(ctx, w.cfg.BatchSize, w.cfg.StaleAfter)
+
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("⚠️ Failed to claim webhook deliveries", zap.Error(err))
+		}
+		return
+	}
+
+	// claimed deliveries must be finished even when shutdown starts mid-batch
+	sendCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(d entity.WebhookDelivery) {
+			defer wg.Done()
+			w.deliver(sendCtx, d)
+		}(delivery)
+	}
+
+	wg.Wait()
+}
+
+func (w *WebhookWorker) deliver(ctx context.Context, delivery entity.WebhookDelivery) {
+	result, err := callWebhook(ctx, delivery.Url, []byte(delivery.Payload), delivery.Project.WebhookSecret)
+
+	delivery.Attempts++
+
+	attempt := entity.WebhookDeliveryAttempt{
+		DeliveryId:     delivery.ID,
+		Attempt:        delivery.Attempts,
+		RequestPayload: delivery.Payload,
+		ResponseBody:   result.Body,
+		LatencyMs:      result.Latency.Milliseconds(),
+	}
+
+	if result.StatusCode != 0 {
+		attempt.ResponseStatus = &result.StatusCode
+	}
+
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	if err == nil {
+		now := time.Now()
+		delivery.Status = entity.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+
+		w.logger.Info("✅ Webhook delivered", zap.Uint("DeliveryId", delivery.ID), zap.Uint("ProjectId", delivery.ProjectId), zap.Int("Attempts", delivery.Attempts))
+	} else if delivery.Attempts >= w.cfg.MaxAttempts {
+		delivery.Status = entity.WebhookDeliveryDead
+		delivery.LastError = err.Error()
+
+		w.logger.Error("❌ Webhook delivery dead", zap.Uint("DeliveryId", delivery.ID), zap.Uint("ProjectId", delivery.ProjectId), zap.Int("Attempts", delivery.Attempts), zap.Error(err))
+	} else {
+		delivery.Status = entity.WebhookDeliveryPending
+		delivery.NextAttemptAt = time.Now().Add(w.backoff(delivery.Attempts))
+		delivery.LastError = err.Error()
+
+		w.logger.Warn("⚠️ Webhook delivery failed, will retry", zap.Uint("DeliveryId", delivery.ID), zap.Uint("ProjectId", delivery.ProjectId), zap.Int("Attempts", delivery.Attempts), zap.Time("NextAttemptAt", delivery.NextAttemptAt), zap.Error(err))
+	}
+
+	if err := w.store.IWebhookQueue.SaveDeliveryResult(ctx, delivery, attempt); err != nil {
+		w.logger.Error("⚠️ Failed to save webhook delivery result", zap.Uint("DeliveryId", delivery.ID), zap.Error(err))
+	}
+}
+
+// backoff returns BaseBackoff * 2^(attempts-1) capped at MaxBackoff, with jitter between
+// d/2 and d so deliveries failing together do not retry in lockstep.
+func (w *WebhookWorker) backoff(attempts int) time.Duration {
+	d := w.cfg.BaseBackoff
+
+	for i := 1; i < attempts && d < w.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > w.cfg.MaxBackoff {
+		d = w.cfg.MaxBackoff
+	}
+
+	half := d / 2
+
+	return half + rand.N(half+1)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestWebhookWorkerBackoff(t *testing.T) {
+	w := NewWebhookWorker(store.Storage{}, zap.NewNop(), WebhookWorkerConfig{
+		BaseBackoff: 30 * time.Second,
+		MaxBackoff:  10 * time.Minute,
+	})
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{5, 8 * time.Minute},
+		{6, 10 * time.Minute},
+		{50, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		// the jitter keeps each wait between half and the whole of the schedule
+		for range 100 {
+			if got := w.backoff(tt.attempts); got < tt.want/2 || got > tt.want {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempts, got, tt.want/2, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// Package sso logs users in with OpenID Connect providers: the authorization code flow
+// with PKCE, ID token validation, and discovery documents cached per provider. The
+// state of a login travels in a signed Flow the caller keeps in a cookie between Start
+// and Finish, so any replica can finish a login.
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+const (
+	DefaultDiscoveryTTL = time.Hour
+	// time the user has to log in at the provider
+	FlowTTL = 10 * time.Minute
+
+	// purpose claim of sealed flows, the Authentication middleware rejects tokens with a
+	// purpose
+	flowPurpose = "oidc_flow"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown oidc provider")
+	// ErrInvalidFlow is returned by Finish for a missing, expired or tampered flow, or a
+	// state that isn't the one of the flow.
+	ErrInvalidFlow = errors.New("invalid or expired oidc login")
+)
+
+type ProviderConfig struct {
+	// name of the provider in the /v1/auth/oidc/{provider} urls
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// the callback url registered at the provider
+	RedirectURL string
+	// "openid" is always requested, email and profile when empty
+	Scopes []string
+}
+
+type Config struct {
+	Providers []ProviderConfig
+	// signs the flows
+	Secret []byte
+	// how long a discovery document is used before it is fetched again,
+	// DefaultDiscoveryTTL when 0
+	DiscoveryTTL time.Duration
+	// http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// Identity is the user a provider logged in.
+type Identity struct {
+	Provider string
+	// sub claim, stable per provider unlike the email
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type SSO struct {
+	cfg       Config
+	providers map[string]*provider
+}
+
+type provider struct {
+	cfg ProviderConfig
+
+	// guards the cached discovery, held while fetching so a provider is fetched once
+	mu        sync.Mutex
+	oidc      *oidc.Provider
+	verifier  *oidc.IDTokenVerifier
+	fetchedAt time.Time
+}
+
+type flowClaims struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	// PKCE code verifier
+	Verifier string `json:"verifier"`
+	Purpose  string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+func New(cfg Config) *SSO {
+	if cfg.DiscoveryTTL <= 0 {
+		cfg.DiscoveryTTL = DefaultDiscoveryTTL
+	}
+
+	providers := make(map[string]*provider, len(cfg.Providers))
+
+	for _, p := range cfg.Providers {
+		if len(p.Scopes) == 0 {
+			p.Scopes = []string{"email", "profile"}
+		}
+
+		providers[p.Name] = &provider{cfg: p}
+	}
+
+	return &SSO{
+		cfg:       cfg,
+		providers: providers,
+	}
+}
+
+// Providers returns the names of the configured providers.
+func (s *SSO) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+
+	for name := range s.providers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Start begins a login at the provider name. It returns the url to redirect the user
+// to and the sealed flow to hand to Finish.
+func (s *SSO) Start(ctx context.Context, name string) (string, string, error) {
+	p, ok := s.providers[name]
+
+	if !ok {
+		return "", "", ErrUnknownProvider
+	}
+
+	oauthCfg, _, err := s.discover(ctx, p)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := randomString()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	nonce, err := randomString()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	verifier := oauth2.GenerateVerifier()
+
+	flow, err := jwt.NewWithClaims(jwt.SigningMethodHS256, flowClaims{
+		Provider: name,
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+		Purpose:  flowPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(FlowTTL)),
+		},
+	}).SignedString(s.cfg.Secret)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL := oauthCfg.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+
+	return authURL, flow, nil
+}
+
+// Finish exchanges the code the provider name redirected back with, and returns the
+// identity of its validated ID token. flow is the one of Start, state the one of the
+// redirect.
+func (s *SSO) Finish(ctx context.Context, name string, flow string, state string, code string) (Identity, error) {
+	p, ok := s.providers[name]
+
+	if !ok {
+		return Identity{}, ErrUnknownProvider
+	}
+
+	var claims flowClaims
+
+	_, err := jwt.ParseWithClaims(flow, &claims, func(token *jwt.Token) (any, error) {
+		return s.cfg.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+
+	if err != nil || claims.Purpose != flowPurpose || claims.Provider != name {
+		return Identity{}, ErrInvalidFlow
+	}
+
+	// the flow is bound to the browser that started the login, a state of another login
+	// is refused
+	if subtle.ConstantTimeCompare([]byte(claims.State), []byte(state)) != 1 {
+		return Identity{}, ErrInvalidFlow
+	}
+
+	oauthCfg, verifier, err := s.discover(ctx, p)
+
+	if err != nil {
+		return Identity{}, err
+	}
+
+	token, err := oauthCfg.Exchange(s.clientContext(ctx), code, oauth2.VerifierOption(claims.Verifier))
+
+	if err != nil {
+		return Identity{}, fmt.Errorf("exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+
+	if !ok {
+		return Identity{}, errors.New("no id_token in the token response")
+	}
+
+	idToken, err := verifier.Verify(s.clientContext(ctx), rawIDToken)
+
+	if err != nil {
+		return Identity{}, fmt.Errorf("verify id_token: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(claims.Nonce)) != 1 {
+		return Identity{}, errors.New("id_token nonce mismatch")
+	}
+
+	var idClaims struct {
+		Email string `json:"email"`
+		// some providers send it as a string
+		EmailVerified any    `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+
+	if err := idToken.Claims(&idClaims); err != nil {
+		return Identity{}, fmt.Errorf("decode id_token claims: %w", err)
+	}
+
+	return Identity{
+		Provider:      name,
+		Subject:       idToken.Subject,
+		Email:         strings.TrimSpace(idClaims.Email),
+		EmailVerified: idClaims.EmailVerified == true || idClaims.EmailVerified == "true",
+		Name:          idClaims.Name,
+	}, nil
+}
+
+// discover returns the oauth2 config and ID token verifier of p, from its discovery
+// document cached for DiscoveryTTL. A provider that can't be reached keeps its last
+// document.
+func (s *SSO) discover(ctx context.Context, p *provider) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oidc == nil || time.Since(p.fetchedAt) >= s.cfg.DiscoveryTTL {
+		discovered, err := oidc.NewProvider(s.clientContext(ctx), p.cfg.Issuer)
+
+		if err != nil && p.oidc == nil {
+			return nil, nil, fmt.Errorf("discover %s: %w", p.cfg.Name, err)
+		}
+
+		if err == nil {
+			p.oidc = discovered
+			p.verifier = discovered.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+			p.fetchedAt = time.Now()
+		}
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     p.oidc.Endpoint(),
+		Scopes:       append([]string{oidc.ScopeOpenID}, p.cfg.Scopes...),
+	}, p.verifier, nil
+}
+
+// clientContext makes the oidc and oauth2 packages use HTTPClient.
+func (s *SSO) clientContext(ctx context.Context) context.Context {
+	if s.cfg.HTTPClient == nil {
+		return ctx
+	}
+
+	return oidc.ClientContext(ctx, s.cfg.HTTPClient)
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
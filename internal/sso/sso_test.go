@@ -0,0 +1,248 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/internal/sso/ssotest"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var testSecret = []byte("test-secret")
+
+func newTestSSO(t *testing.T) (*SSO, *ssotest.Issuer) {
+	issuer := ssotest.NewIssuer(t)
+
+	s := New(Config{
+		Secret: testSecret,
+		Providers: []ProviderConfig{{
+			Name:         "test",
+			Issuer:       issuer.URL,
+			ClientID:     "client",
+			ClientSecret: "client-secret",
+			RedirectURL:  "https://logstream.example.com/v1/auth/oidc/test/callback",
+		}},
+	})
+
+	return s, issuer
+}
+
+// start begins a login and logs the user in at the issuer, it returns the flow, state
+// and code Finish gets.
+func start(t *testing.T, s *SSO, issuer *ssotest.Issuer) (string, string, string) {
+	t.Helper()
+
+	authURL, flow, err := s.Start(context.Background(), "test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, state := issuer.Authorize(t, authURL)
+
+	return flow, state, code
+}
+
+func TestFinish(t *testing.T) {
+	s, issuer := newTestSSO(t)
+	issuer.Claims["email"] = " a@example.com "
+	issuer.Claims["email_verified"] = true
+	issuer.Claims["name"] = "A"
+
+	flow, state, code := start(t, s, issuer)
+
+	identity, err := s.Finish(context.Background(), "test", flow, state, code)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Identity{Provider: "test", Subject: "user-1", Email: "a@example.com", EmailVerified: true, Name: "A"}
+
+	if identity != want {
+		t.Fatalf("got %+v, want %+v", identity, want)
+	}
+
+	// codes work once
+	if _, err := s.Finish(context.Background(), "test", flow, state, code); err == nil {
+		t.Fatal("a used code logged in again")
+	}
+}
+
+func TestFinishUnknownProvider(t *testing.T) {
+	s, issuer := newTestSSO(t)
+	flow, state, code := start(t, s, issuer)
+
+	if _, err := s.Finish(context.Background(), "other", flow, state, code); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("got %v, want %v", err, ErrUnknownProvider)
+	}
+}
+
+// TestFinishPKCE sends the code of a login with the flow of another one, the issuer
+// refuses it because the verifier of the flow doesn't match the challenge of the code.
+func TestFinishPKCE(t *testing.T) {
+	s, issuer := newTestSSO(t)
+
+	_, _, code := start(t, s, issuer)
+	otherFlow, otherState, _ := start(t, s, issuer)
+
+	_, err := s.Finish(context.Background(), "test", otherFlow, otherState, code)
+
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("got %v, want the exchange refused with invalid_grant", err)
+	}
+}
+
+func TestFinishStateMismatch(t *testing.T) {
+	s, issuer := newTestSSO(t)
+
+	flow, _, code := start(t, s, issuer)
+	_, otherState, _ := start(t, s, issuer)
+
+	for _, state := range []string{otherState, ""} {
+		if _, err := s.Finish(context.Background(), "test", flow, state, code); !errors.Is(err, ErrInvalidFlow) {
+			t.Fatalf("state %q got %v, want %v", state, err, ErrInvalidFlow)
+		}
+	}
+}
+
+func TestFinishNonceMismatch(t *testing.T) {
+	s, issuer := newTestSSO(t)
+	issuer.Claims["nonce"] = "nonce-of-another-login"
+
+	flow, state, code := start(t, s, issuer)
+
+	_, err := s.Finish(context.Background(), "test", flow, state, code)
+
+	if err == nil || !strings.Contains(err.Error(), "nonce mismatch") {
+		t.Fatalf("got %v, want a nonce mismatch", err)
+	}
+}
+
+func TestFinishInvalidFlow(t *testing.T) {
+	s, issuer := newTestSSO(t)
+	_, state, code := start(t, s, issuer)
+
+	seal := func(secret []byte, claims flowClaims) string {
+		flow, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return flow
+	}
+
+	valid := func() flowClaims {
+		return flowClaims{
+			Provider: "test",
+			State:    state,
+			Purpose:  flowPurpose,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(FlowTTL)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		flow func() string
+	}{
+		{"expired", func() string {
+			claims := valid()
+			claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+			return seal(testSecret, claims)
+		}},
+		{"no expiry", func() string {
+			claims := valid()
+			claims.ExpiresAt = nil
+			return seal(testSecret, claims)
+		}},
+		{"other secret", func() string {
+			return seal([]byte("other-secret"), valid())
+		}},
+		{"other provider", func() string {
+			claims := valid()
+			claims.Provider = "other"
+			return seal(testSecret, claims)
+		}},
+		{"other purpose", func() string {
+			claims := valid()
+			claims.Purpose = ""
+			return seal(testSecret, claims)
+		}},
+		{"missing", func() string {
+			return ""
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Finish(context.Background(), "test", tt.flow(), state, code); !errors.Is(err, ErrInvalidFlow) {
+				t.Fatalf("got %v, want %v", err, ErrInvalidFlow)
+			}
+		})
+	}
+}
+
+// TestFinishEmailVerified checks only an email the provider verified is reported as
+// verified, LinkIdentity links accounts by verified emails only.
+func TestFinishEmailVerified(t *testing.T) {
+	tests := []struct {
+		name          string
+		emailVerified any
+		want          bool
+	}{
+		{"true", true, true},
+		{"string true", "true", true},
+		{"false", false, false},
+		{"string false", "false", false},
+		{"other string", "yes", false},
+		{"missing", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, issuer := newTestSSO(t)
+			issuer.Claims["email"] = "a@example.com"
+
+			if tt.emailVerified != nil {
+				issuer.Claims["email_verified"] = tt.emailVerified
+			}
+
+			flow, state, code := start(t, s, issuer)
+
+			identity, err := s.Finish(context.Background(), "test", flow, state, code)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if identity.EmailVerified != tt.want {
+				t.Fatalf("got EmailVerified %v, want %v", identity.EmailVerified, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoveryIsCached(t *testing.T) {
+	s, issuer := newTestSSO(t)
+	flow, state, code := start(t, s, issuer)
+
+	// an unreachable provider keeps its last discovery document, the token endpoint
+	// being down too makes Finish fail at the exchange rather than the discovery
+	issuer.Close()
+
+	if _, _, err := s.Start(context.Background(), "test"); err != nil {
+		t.Fatalf("start with a cached discovery got %v", err)
+	}
+
+	_, err := s.Finish(context.Background(), "test", flow, state, code)
+
+	if err == nil || !strings.Contains(err.Error(), "exchange code") {
+		t.Fatalf("got %v, want the exchange to fail", err)
+	}
+}
@@ -2,65 +2,411 @@ package store
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
 	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
 	db "github.com/ariefzainuri96/go-logstream/internal/db"
+	"github.com/ariefzainuri96/go-logstream/internal/jwtkeys"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AuthStore struct {
 	gormDb *db.GormDB
+	// signs and verifies the JWTs
+	keys *jwtkeys.KeyManager
 }
 
-func (store *AuthStore) Login(ctx context.Context, body request.LoginRequest) (entity.User, string, error) {
+const (
+	// access tokens can't be revoked before they expire once their jti is checked, keep them short
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = time.Hour * 24 * 30
+
+	passwordResetTTL = time.Hour
+
+	emailVerificationTTL = time.Hour * 48
+	// purpose claim of email verification tokens, the Authentication middleware
+	// rejects tokens with a purpose
+	emailVerificationPurpose = "email_verification"
+
+	// the password was right but a TOTP code is still due, keep it short
+	mfaChallengeTTL     = 5 * time.Minute
+	mfaChallengePurpose = "mfa_challenge"
+
+	totpIssuer = "LogStream"
+	// seconds per TOTP code, codes of the previous and next step are accepted too for
+	// clock drift
+	totpPeriod = 30
+
+	recoveryCodeCount = 10
+)
+
+// ErrRefreshTokenReused is returned when a rotated refresh token is used again, its
+// family is revoked: either the client or an attacker holds a stolen copy.
+var ErrRefreshTokenReused = errors.New("refresh token reused")
+
+var (
+	// ErrInvalidTotpCode is returned for a wrong, expired or already used TOTP or
+	// recovery code.
+	ErrInvalidTotpCode    = errors.New("invalid 2fa code")
+	ErrTotpAlreadyEnabled = errors.New("2fa is already enabled")
+	// ErrTotpNotEnrolled is returned when confirming without enrolling first.
+	ErrTotpNotEnrolled = errors.New("2fa is not enrolled")
+	ErrTotpNotEnabled  = errors.New("2fa is not enabled")
+)
+
+// ErrIdentityEmailNotVerified is returned by LinkIdentity for a new identity whose
+// email the provider didn't verify, it can't be linked to a user.
+var ErrIdentityEmailNotVerified = errors.New("the provider didn't verify the email")
+
+// GetUser returns the user of userId, gorm.ErrRecordNotFound when there is none.
+func (store *AuthStore) GetUser(ctx context.Context, userId uint) (entity.User, error) {
+	var user entity.User
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.First(&user, userId).Error
+	})
+
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+// CheckPassword returns the user of body.Email when body.Password is theirs.
+func (store *AuthStore) CheckPassword(ctx context.Context, body request.LoginRequest) (entity.User, error) {
 	var user entity.User
 
 	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
 		// get data by condition from user instance, which is by email
 		return tx.
 			Model(&entity.User{}).
-			Where("email = ?", body.Email).
+			Where("LOWER(users.email) = LOWER(?)", body.Email).
 			// insert data to [user] address
 			First(&user).Error
 	})
 
 	if err != nil {
-		return user, "", err
+		return user, err
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(body.Password))
 
 	if err != nil {
-		return user, "", errors.New("invalid email or password")
+		return user, errors.New("invalid email or password")
+	}
+
+	return user, nil
+}
+
+// IssueTokens starts a new login of user: an access token and the first refresh token
+// of a new family. The caller must have authenticated the user.
+func (store *AuthStore) IssueTokens(ctx context.Context, user entity.User) (entity.AuthTokens, error) {
+	familyId, err := randomToken(16)
+
+	if err != nil {
+		return entity.AuthTokens{}, err
 	}
 
-	token, err := generateToken(body.Email, int(user.ID))
+	var tokens entity.AuthTokens
+
+	err = store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		tokens, err = store.issueTokens(tx, user, familyId)
+		return err
+	})
 
 	if err != nil {
-		return user, "", err
+		return entity.AuthTokens{}, err
 	}
 
-	return user, token, nil
+	return tokens, nil
+}
+
+func randomToken(size int) (string, error) {
+	b := make([]byte, size)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
 }
 
-func generateToken(email string, id int) (string, error) {
-	jwtSecret := strings.TrimSpace(os.Getenv("SECRET_KEY"))
+// hashToken returns the hex SHA-256 of a refresh token, tokens are random so a slow
+// password hash isn't needed.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// issueTokens creates an access token and a refresh token of familyId for user, the
+// refresh token row keeps the jti of the access token so it is revoked with the family.
+func (store *AuthStore) issueTokens(tx *gorm.DB, user entity.User, familyId string) (entity.AuthTokens, error) {
+	jti, err := randomToken(16)
+
+	if err != nil {
+		return entity.AuthTokens{}, err
+	}
+
+	refreshToken, err := randomToken(32)
 
+	if err != nil {
+		return entity.AuthTokens{}, err
+	}
+
+	accessToken, err := store.generateToken(user.Email, int(user.ID), jti)
+
+	if err != nil {
+		return entity.AuthTokens{}, err
+	}
+
+	err = tx.Create(&entity.RefreshToken{
+		UserId:    user.ID,
+		FamilyId:  familyId,
+		TokenHash: hashToken(refreshToken),
+		AccessJti: jti,
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}).Error
+
+	if err != nil {
+		return entity.AuthTokens{}, err
+	}
+
+	return entity.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(accessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (store *AuthStore) generateToken(email string, id int, jti string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": id,
 		"email":   email,
-		"exp":     time.Now().Add(time.Hour * 24 * 30).Unix(), // Token valid for 30 day
+		"jti":     jti,
+		"exp":     time.Now().Add(accessTokenTTL).Unix(),
+	}
+
+	return store.keys.Sign(claims)
+}
+
+// purposeToken signs a token proving something about user for ttl. It isn't signed
+// with the published keys and its audience is the purpose, so it can't be used as an
+// access token or for another purpose.
+func (store *AuthStore) purposeToken(user entity.User, purpose string, ttl time.Duration) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"purpose": purpose,
+		"exp":     time.Now().Add(ttl).Unix(),
+	}
+
+	return store.keys.SignPurpose(purpose, claims)
+}
+
+// parsePurposeToken returns the user id and email of a valid token of purpose.
+func (store *AuthStore) parsePurposeToken(tokenString string, purpose string) (uint, string, bool) {
+	claims, err := store.keys.ParsePurpose(tokenString, purpose)
+
+	if err != nil || claims["purpose"] != purpose {
+		return 0, "", false
+	}
+
+	userId, _ := claims["user_id"].(float64) // json numbers are float64
+	email, _ := claims["email"].(string)
+
+	return uint(userId), email, userId > 0
+}
+
+// RequestEmailVerification returns the user of req.Email and a signed token proving
+// they own the email, gorm.ErrRecordNotFound for an unknown email.
+func (store *AuthStore) RequestEmailVerification(ctx context.Context, req request.ResendVerificationRequest) (entity.User, string, error) {
+	var user entity.User
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("LOWER(users.email) = LOWER(?)", req.Email).
+			First(&user).
+			Error
+	})
+
+	if err != nil {
+		return entity.User{}, "", err
+	}
+
+	token, err := store.purposeToken(user, emailVerificationPurpose, emailVerificationTTL)
+
+	if err != nil {
+		return entity.User{}, "", err
+	}
+
+	return user, token, nil
+}
+
+// VerifyEmail marks the email of the token of req verified and returns the user. An
+// invalid or expired token, or one for an email the user no longer has, is
+// gorm.ErrRecordNotFound. Verifying twice is fine.
+func (store *AuthStore) VerifyEmail(ctx context.Context, req request.VerifyEmailRequest) (entity.User, error) {
+	userId, email, ok := store.parsePurposeToken(req.Token, emailVerificationPurpose)
+
+	if !ok {
+		return entity.User{}, gorm.ErrRecordNotFound
+	}
+
+	var user entity.User
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		result := tx.
+			Model(&entity.User{}).
+			Where("users.id = ? AND users.email = ?", userId, email).
+			Update("email_verified_at", gorm.Expr("COALESCE(email_verified_at, ?)", time.Now()))
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.First(&user, userId).Error
+	})
+
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+func revokeFamily(tx *gorm.DB, familyId string) error {
+	return tx.
+		Model(&entity.RefreshToken{}).
+		Where("refresh_tokens.family_id = ? AND refresh_tokens.revoked_at IS NULL", familyId).
+		Update("revoked_at", time.Now()).
+		Error
+}
+
+// Refresh rotates refreshToken: it is marked used and a new pair of the same family is
+// returned. An unknown, expired or revoked token is gorm.ErrRecordNotFound, a used one
+// revokes the family and is ErrRefreshTokenReused.
+func (store *AuthStore) Refresh(ctx context.Context, refreshToken string) (entity.AuthTokens, error) {
+	var tokens entity.AuthTokens
+	reused := false
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			var current entity.RefreshToken
+
+			// concurrent refreshes of the same token wait here, the second sees it used
+			err := tx.
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("refresh_tokens.token_hash = ?", hashToken(refreshToken)).
+				First(&current).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			if current.RevokedAt != nil || time.Now().After(current.ExpiresAt) {
+				return gorm.ErrRecordNotFound
+			}
+
+			// the revocation is committed, the error is returned after the transaction
+			if current.UsedAt != nil {
+				reused = true
+				return revokeFamily(tx, current.FamilyId)
+			}
+
+			err = tx.
+				Model(&current).
+				Update("used_at", time.Now()).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			var user entity.User
+
+			err = tx.First(&user, current.UserId).Error
+
+			if err != nil {
+				return err
+			}
+
+			tokens, err = store.issueTokens(tx, user, current.FamilyId)
+			return err
+		})
+	})
+
+	if err != nil {
+		return entity.AuthTokens{}, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(jwtSecret))
+	if reused {
+		return entity.AuthTokens{}, ErrRefreshTokenReused
+	}
+
+	return tokens, nil
+}
+
+// Logout revokes the family of refreshToken, the access tokens issued with it are
+// rejected from then on.
+func (store *AuthStore) Logout(ctx context.Context, refreshToken string) error {
+	return store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		var current entity.RefreshToken
+
+		err := tx.
+			Select("family_id").
+			Where("refresh_tokens.token_hash = ?", hashToken(refreshToken)).
+			First(&current).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		return revokeFamily(tx, current.FamilyId)
+	})
+}
+
+// IsTokenRevoked reports whether the access token jti was revoked, a jti that wasn't
+// issued counts as revoked.
+func (store *AuthStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var token entity.RefreshToken
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Select("revoked_at").
+			Where("refresh_tokens.access_jti = ?", jti).
+			First(&token).
+			Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return token.RevokedAt != nil, nil
 }
 
 func (store *AuthStore) Register(ctx context.Context, body request.RegisterRequest) (uint, error) {
@@ -70,7 +416,7 @@ func (store *AuthStore) Register(ctx context.Context, body request.RegisterReque
 		return tx.
 			Model(&entity.User{}).
 			Select("1"). // return 1 if email exists (this is signal that row exists)
-			Where("email = ?", body.Email).
+			Where("LOWER(users.email) = LOWER(?)", body.Email).
 			Limit(1).          // stop query when row found
 			Scan(&emaiExists). // the destination value is bool, and sql convert value from "1" to true
 			Error
@@ -91,12 +437,14 @@ func (store *AuthStore) Register(ctx context.Context, body request.RegisterReque
 	}
 
 	user := entity.User{
-		Email:    body.Email,
+		Email:    normalizeEmail(body.Email),
 		Password: string(hashedPassword),
 	}
 
 	err = store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
-		return tx.Create(&user).Error
+		return tx.Transaction(func(tx *gorm.DB) error {
+			return createUser(tx, &user)
+		})
 	})
 
 	if err != nil {
@@ -106,25 +454,570 @@ func (store *AuthStore) Register(ctx context.Context, body request.RegisterReque
 	return user.ID, nil
 }
 
-func (store *AuthStore) ForgotPassword(ctx context.Context, body request.LoginRequest) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(body.Password), bcrypt.DefaultCost)
+// normalizeEmail is the email stored for a new user. Emails are unique whatever their
+// case (idx_users_email_lower) and looked up with LOWER, older users may still have
+// upper case letters.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// createUser creates user and its personal org, the org owns the projects the user
+// creates.
+func createUser(tx *gorm.DB, user *entity.User) error {
+	err := tx.Create(user).Error
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&entity.Organization{
+		Name:           user.Email,
+		PersonalUserId: &user.ID,
+		Members: []entity.OrgMember{
+			{
+				UserId: user.ID,
+				Role:   entity.ProjectRoleOwner,
+			},
+		},
+	}).Error
+}
+
+// unusablePasswordHash is the password hash of users without a password, a random
+// password nobody knows. They can set one with ForgotPassword.
+func unusablePasswordHash() (string, error) {
+	password, err := randomToken(32)
+
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 
 	if err != nil {
 		return "", err
 	}
 
-	result := store.gormDb.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+	return string(hash), nil
+}
+
+// LinkIdentity returns the user of an identity of an OIDC provider. An identity seen
+// before logs in its user, a new one is linked to the user of its email, or to a new
+// user, once the provider verified the email: ErrIdentityEmailNotVerified otherwise.
+func (store *AuthStore) LinkIdentity(ctx context.Context, identity entity.UserIdentity) (entity.User, error) {
+	// hashed out of the transaction, bcrypt is slow
+	passwordHash, err := unusablePasswordHash()
+
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	now := time.Now()
+
+	var user entity.User
+
+	err = store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			var linked entity.UserIdentity
+
+			err := tx.
+				Where("provider = ? AND subject = ?", identity.Provider, identity.Subject).
+				First(&linked).
+				Error
+
+			if err == nil {
+				err = tx.
+					Model(&linked).
+					Updates(map[string]any{
+						"email":         identity.Email,
+						"last_login_at": now,
+					}).
+					Error
+
+				if err != nil {
+					return err
+				}
+
+				return tx.First(&user, linked.UserId).Error
+			}
+
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			if !identity.EmailVerified || identity.Email == "" {
+				return ErrIdentityEmailNotVerified
+			}
+
+			err = tx.
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("LOWER(users.email) = LOWER(?)", identity.Email).
+				First(&user).
+				Error
+
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				user = entity.User{
+					Email:           normalizeEmail(identity.Email),
+					Password:        passwordHash,
+					EmailVerifiedAt: &now,
+				}
+
+				if err := createUser(tx, &user); err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			case user.EmailVerifiedAt == nil:
+				// whoever registered the email never proved owning it, their password,
+				// 2FA and logins go before the owner gets the account
+				err := tx.
+					Model(&user).
+					Updates(map[string]any{
+						"password_hash":     passwordHash,
+						"email_verified_at": now,
+						"totp_secret":       nil,
+						"totp_enabled_at":   nil,
+						"totp_last_step":    nil,
+					}).
+					Error
+
+				if err != nil {
+					return err
+				}
+
+				err = tx.
+					Where("user_id = ?", user.ID).
+					Delete(&entity.UserRecoveryCode{}).
+					Error
+
+				if err != nil {
+					return err
+				}
+
+				err = tx.
+					Model(&entity.RefreshToken{}).
+					Where("refresh_tokens.user_id = ? AND refresh_tokens.revoked_at IS NULL", user.ID).
+					Update("revoked_at", now).
+					Error
+
+				if err != nil {
+					return err
+				}
+
+				if err := tx.First(&user, user.ID).Error; err != nil {
+					return err
+				}
+			}
+
+			identity.UserId = user.ID
+			identity.LastLoginAt = &now
+
+			return tx.Create(&identity).Error
+		})
+	})
+
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+// ForgotPassword creates a password reset token for the user of req.Email, the
+// returned token carries the token to email in Token. An unknown email is
+// gorm.ErrRecordNotFound.
+func (store *AuthStore) ForgotPassword(ctx context.Context, req request.ForgotPasswordRequest) (entity.PasswordResetToken, error) {
+	var user entity.User
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
 		return tx.
+			Select("id").
+			Where("LOWER(users.email) = LOWER(?)", req.Email).
+			First(&user).
+			Error
+	})
+
+	if err != nil {
+		return entity.PasswordResetToken{}, err
+	}
+
+	token, err := randomToken(32)
+
+	if err != nil {
+		return entity.PasswordResetToken{}, err
+	}
+
+	resetToken := entity.PasswordResetToken{
+		UserId:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+
+	err = store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Create(&resetToken).Error
+	})
+
+	if err != nil {
+		return entity.PasswordResetToken{}, err
+	}
+
+	resetToken.Token = token
+
+	return resetToken, nil
+}
+
+// ResetPassword consumes the reset token of req and sets the new password. The other
+// reset tokens of the user are used up and every login of the user is revoked, as the
+// old password may have leaked. An unknown, used or expired token is
+// gorm.ErrRecordNotFound.
+func (store *AuthStore) ResetPassword(ctx context.Context, req request.ResetPasswordRequest) (uint, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var resetToken entity.PasswordResetToken
+
+	err = store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("password_reset_tokens.token_hash = ? AND password_reset_tokens.used_at IS NULL AND password_reset_tokens.expires_at > ?", hashToken(req.Token), time.Now()).
+				First(&resetToken).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+
+			err = tx.
+				Model(&entity.PasswordResetToken{}).
+				Where("password_reset_tokens.user_id = ? AND password_reset_tokens.used_at IS NULL", resetToken.UserId).
+				Update("used_at", now).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			err = tx.
+				Model(&entity.User{}).
+				Where("users.id = ?", resetToken.UserId).
+				Update("password_hash", string(hashedPassword)).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			return tx.
+				Model(&entity.RefreshToken{}).
+				Where("refresh_tokens.user_id = ? AND refresh_tokens.revoked_at IS NULL", resetToken.UserId).
+				Update("revoked_at", now).
+				Error
+		})
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return resetToken.UserId, nil
+}
+
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// IssueMfaChallenge returns a short lived token proving user passed the password
+// check, the service exchanges it and a code for the tokens of the login.
+func (store *AuthStore) IssueMfaChallenge(ctx context.Context, user entity.User) (string, error) {
+	return store.purposeToken(user, mfaChallengePurpose, mfaChallengeTTL)
+}
+
+// ParseMfaChallenge returns the user of an MFA challenge token, gorm.ErrRecordNotFound
+// when the token is invalid or expired, or the user no longer has 2FA.
+func (store *AuthStore) ParseMfaChallenge(ctx context.Context, mfaToken string) (entity.User, error) {
+	userId, email, ok := store.parsePurposeToken(mfaToken, mfaChallengePurpose)
+
+	if !ok {
+		return entity.User{}, gorm.ErrRecordNotFound
+	}
+
+	var user entity.User
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("users.id = ? AND users.email = ? AND users.totp_enabled_at IS NOT NULL", userId, email).
+			First(&user).
+			Error
+	})
+
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+// CheckTotpCode uses up code for user, a code of their authenticator app or one of
+// their recovery codes. Each code works once, ErrInvalidTotpCode otherwise.
+func (store *AuthStore) CheckTotpCode(ctx context.Context, user entity.User, code string) error {
+	if user.TotpEnabledAt == nil {
+		return ErrTotpNotEnabled
+	}
+
+	code = normalizeCode(code)
+
+	return store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		if len(code) == int(otp.DigitsSix) {
+			return useTotpCode(tx, user, code, false)
+		}
+
+		result := tx.
+			Model(&entity.UserRecoveryCode{}).
+			Where("user_id = ? AND code_hash = ? AND used_at IS NULL", user.ID, hashToken(code)).
+			Update("used_at", time.Now())
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrInvalidTotpCode
+		}
+
+		return nil
+	})
+}
+
+// useTotpCode accepts code when it is the one of the current time step of the secret of
+// user, or of the step before or after, and no later code was used yet. enable turns
+// 2FA on with it.
+func useTotpCode(tx *gorm.DB, user entity.User, code string, enable bool) error {
+	now := time.Now().Unix() / totpPeriod
+
+	for _, step := range []int64{now - 1, now, now + 1} {
+		expected, err := totp.GenerateCodeCustom(user.TotpSecret, time.Unix(step*totpPeriod, 0), totpOpts)
+
+		if err != nil {
+			return err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) != 1 {
+			continue
+		}
+
+		updates := map[string]any{"totp_last_step": step}
+
+		if enable {
+			updates["totp_enabled_at"] = time.Now()
+		}
+
+		// the condition makes concurrent uses of the same code fail but one
+		result := tx.
+			Model(&entity.User{}).
+			Where("users.id = ? AND (users.totp_last_step IS NULL OR users.totp_last_step < ?)", user.ID, step).
+			Updates(updates)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrInvalidTotpCode
+		}
+
+		return nil
+	}
+
+	return ErrInvalidTotpCode
+}
+
+// normalizeCode drops the spaces and dashes users type in codes.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+// EnrollTotp creates a new TOTP secret for the user, pending until ConfirmTotp. Enrolling
+// again replaces a pending secret.
+func (store *AuthStore) EnrollTotp(ctx context.Context, userId uint) (entity.TotpEnrollment, error) {
+	var user entity.User
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.First(&user, userId).Error
+	})
+
+	if err != nil {
+		return entity.TotpEnrollment{}, err
+	}
+
+	if user.TotpEnabledAt != nil {
+		return entity.TotpEnrollment{}, ErrTotpAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      totpIssuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+
+	if err != nil {
+		return entity.TotpEnrollment{}, err
+	}
+
+	err = store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		result := tx.
 			Model(&entity.User{}).
-			Where("email = ?", body.Email).
-			Updates(entity.User{Password: string(hashedPassword)})
+			Where("users.id = ? AND users.totp_enabled_at IS NULL", userId).
+			Updates(map[string]any{
+				"totp_secret":    key.Secret(),
+				"totp_last_step": nil,
+			})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrTotpAlreadyEnabled
+		}
+
+		return nil
 	})
 
-	if result.Error != nil {
-		return "", err
-	} else if result.RowsAffected == 0 {
-		return "", errors.New("email not found")
+	if err != nil {
+		return entity.TotpEnrollment{}, err
 	}
 
-	return "Success forgot password", nil
+	return entity.TotpEnrollment{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+	}, nil
+}
+
+// ConfirmTotp turns 2FA on with a code of the pending secret, proving the authenticator
+// app has it, and returns the recovery codes. They aren't stored as is, they can't be
+// shown again.
+func (store *AuthStore) ConfirmTotp(ctx context.Context, userId uint, req request.TotpCodeRequest) ([]string, error) {
+	var codes []string
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			var user entity.User
+
+			err := tx.
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				First(&user, userId).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			if user.TotpEnabledAt != nil {
+				return ErrTotpAlreadyEnabled
+			}
+
+			if user.TotpSecret == "" {
+				return ErrTotpNotEnrolled
+			}
+
+			if err := useTotpCode(tx, user, normalizeCode(req.Code), true); err != nil {
+				return err
+			}
+
+			codes, err = replaceRecoveryCodes(tx, userId)
+			return err
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// replaceRecoveryCodes drops the recovery codes of userId and creates new ones.
+func replaceRecoveryCodes(tx *gorm.DB, userId uint) ([]string, error) {
+	err := tx.
+		Where("user_id = ?", userId).
+		Delete(&entity.UserRecoveryCode{}).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	rows := make([]entity.UserRecoveryCode, 0, recoveryCodeCount)
+
+	for range recoveryCodeCount {
+		code, err := randomToken(5)
+
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code[:5]+"-"+code[5:])
+		rows = append(rows, entity.UserRecoveryCode{
+			UserId:   userId,
+			CodeHash: hashToken(code),
+		})
+	}
+
+	if err := tx.Create(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTotp turns 2FA off with a TOTP or recovery code, and drops the secret and the
+// recovery codes.
+func (store *AuthStore) DisableTotp(ctx context.Context, userId uint, req request.TotpCodeRequest) error {
+	var user entity.User
+
+	err := store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.First(&user, userId).Error
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err := store.CheckTotpCode(ctx, user, req.Code); err != nil {
+		return err
+	}
+
+	return store.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Model(&entity.User{}).
+				Where("users.id = ?", userId).
+				Updates(map[string]any{
+					"totp_secret":     nil,
+					"totp_enabled_at": nil,
+					"totp_last_step":  nil,
+				}).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			return tx.
+				Where("user_id = ?", userId).
+				Delete(&entity.UserRecoveryCode{}).
+				Error
+		})
+	})
 }
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// LoginAttemptStore counts login attempts in Postgres, so replicas share them.
+type LoginAttemptStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+// GetLoginAttempt returns the zero attempt for a key without failures.
+func (s *LoginAttemptStore) GetLoginAttempt(ctx context.Context, key string) (entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("login_attempts.key = ?", key).
+			First(&attempt).
+			Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.LoginAttempt{Key: key}, nil
+	}
+
+	if err != nil {
+		return entity.LoginAttempt{}, err
+	}
+
+	return attempt, nil
+}
+
+// RecordLoginFailure counts a failure of key in one statement, concurrent failures of
+// replicas can't be lost. The count restarts when the last failure is older than window.
+func (s *LoginAttemptStore) RecordLoginFailure(ctx context.Context, key string, now time.Time, window time.Duration) (entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Raw(`INSERT INTO login_attempts (key, failures, last_failure_at)
+				VALUES (?, 1, ?)
+				ON CONFLICT (key) DO UPDATE SET
+					failures = CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+					last_failure_at = EXCLUDED.last_failure_at
+				RETURNING key, failures, last_failure_at, locked_until`, key, now, now.Add(-window)).
+			Scan(&attempt).
+			Error
+	})
+
+	if err != nil {
+		return entity.LoginAttempt{}, err
+	}
+
+	return attempt, nil
+}
+
+func (s *LoginAttemptStore) LockLogin(ctx context.Context, key string, until time.Time) error {
+	return s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Model(&entity.LoginAttempt{}).
+			Where("login_attempts.key = ?", key).
+			Update("locked_until", until).
+			Error
+	})
+}
+
+func (s *LoginAttemptStore) ResetLoginAttempts(ctx context.Context, key string) error {
+	return s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("login_attempts.key = ?", key).
+			Delete(&entity.LoginAttempt{}).
+			Error
+	})
+}
+
+// PruneLoginAttempts deletes the keys that failed last before before and aren't
+// locked anymore.
+func (s *LoginAttemptStore) PruneLoginAttempts(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Where("login_attempts.last_failure_at < ? AND (login_attempts.locked_until IS NULL OR login_attempts.locked_until < ?)", before, before).
+			Delete(&entity.LoginAttempt{})
+	})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		s.logger.Info("✅ Pruned login attempts", zap.Int64("Count", result.RowsAffected))
+	}
+
+	return result.RowsAffected, nil
+}
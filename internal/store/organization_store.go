@@ -0,0 +1,265 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type OrganizationStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+// GetOrganizations returns the orgs the user is a member of, the personal org first.
+func (s *OrganizationStore) GetOrganizations(ctx context.Context, userId uint) ([]entity.Organization, error) {
+	var organizations []entity.Organization
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("organizations.id IN (SELECT org_id FROM org_members WHERE user_id = ?)", userId).
+			Order("organizations.personal_user_id IS NULL, organizations.id ASC").
+			Find(&organizations).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+func (s *OrganizationStore) GetOrganization(ctx context.Context, orgId uint) (entity.Organization, error) {
+	var organization entity.Organization
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.First(&organization, orgId).Error
+	})
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+func (s *OrganizationStore) GetPersonalOrganization(ctx context.Context, userId uint) (entity.Organization, error) {
+	var organization entity.Organization
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("organizations.personal_user_id = ?", userId).
+			First(&organization).
+			Error
+	})
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+// AddOrganization creates a shared org, the user becomes its owner.
+func (s *OrganizationStore) AddOrganization(ctx context.Context, userId uint, req request.AddOrganizationRequest) (entity.Organization, error) {
+	organization := entity.Organization{
+		Name:         req.Name,
+		BillingEmail: req.BillingEmail,
+		Members: []entity.OrgMember{
+			{
+				UserId: userId,
+				Role:   entity.ProjectRoleOwner,
+			},
+		},
+	}
+
+	// gorm creates the org and its owner in one transaction
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Create(&organization).Error
+	})
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+func (s *OrganizationStore) UpdateOrganization(ctx context.Context, orgId uint, req request.AddOrganizationRequest) (entity.Organization, error) {
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Model(&entity.Organization{}).
+			Where("organizations.id = ?", orgId).
+			Updates(map[string]any{
+				"name":          req.Name,
+				"billing_email": req.BillingEmail,
+				"updated_at":    time.Now(),
+			})
+	})
+
+	if result.Error != nil {
+		return entity.Organization{}, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.Organization{}, gorm.ErrRecordNotFound
+	}
+
+	var organization entity.Organization
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.First(&organization, orgId).Error
+	})
+
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+// orgMembers selects org members with the email of their user.
+func orgMembers(tx *gorm.DB) *gorm.DB {
+	return tx.
+		Model(&entity.OrgMember{}).
+		Select("org_members.*, users.email").
+		Joins("INNER JOIN users ON users.id = org_members.user_id")
+}
+
+func (s *OrganizationStore) GetOrgMembers(ctx context.Context, orgId uint) ([]entity.OrgMember, error) {
+	var members []entity.OrgMember
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return orgMembers(tx).
+			Where("org_members.org_id = ?", orgId).
+			Order("org_members.id ASC").
+			Find(&members).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s *OrganizationStore) getOrgMember(ctx context.Context, orgId uint, memberId uint) (entity.OrgMember, error) {
+	var member entity.OrgMember
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return orgMembers(tx).
+			Where("org_members.org_id = ? AND org_members.id = ?", orgId, memberId).
+			First(&member).
+			Error
+	})
+
+	if err != nil {
+		return entity.OrgMember{}, err
+	}
+
+	return member, nil
+}
+
+// AddOrgMember adds the user registered with req.Email to the org,
+// gorm.ErrRecordNotFound is returned when there is none. The email must be verified,
+// anyone can register an email they don't own.
+func (s *OrganizationStore) AddOrgMember(ctx context.Context, orgId uint, req request.AddOrgMemberRequest) (entity.OrgMember, error) {
+	var user entity.User
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Select("id", "email").
+			Where("LOWER(users.email) = LOWER(?) AND users.email_verified_at IS NOT NULL", req.Email).
+			First(&user).
+			Error
+	})
+
+	if err != nil {
+		return entity.OrgMember{}, err
+	}
+
+	member := entity.OrgMember{
+		OrgId:  orgId,
+		UserId: user.ID,
+		Role:   req.Role,
+	}
+
+	err = s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Create(&member).Error
+	})
+
+	if err != nil {
+		return entity.OrgMember{}, err
+	}
+
+	member.Email = user.Email
+
+	return member, nil
+}
+
+func (s *OrganizationStore) UpdateOrgMember(ctx context.Context, orgId uint, memberId uint, req request.UpdateOrgMemberRequest) (entity.OrgMember, error) {
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Model(&entity.OrgMember{}).
+			Where("org_members.org_id = ? AND org_members.id = ?", orgId, memberId).
+			Updates(map[string]any{
+				"role":       req.Role,
+				"updated_at": time.Now(),
+			})
+	})
+
+	if result.Error != nil {
+		return entity.OrgMember{}, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.OrgMember{}, gorm.ErrRecordNotFound
+	}
+
+	return s.getOrgMember(ctx, orgId, memberId)
+}
+
+func (s *OrganizationStore) DeleteOrgMember(ctx context.Context, orgId uint, memberId uint) error {
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Where("org_members.org_id = ?", orgId).
+			Delete(&entity.OrgMember{}, memberId)
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetOrgMemberRole returns the role of a user in an org, gorm.ErrRecordNotFound when
+// the user isn't a member.
+func (s *OrganizationStore) GetOrgMemberRole(ctx context.Context, orgId uint, userId uint) (string, error) {
+	var member entity.OrgMember
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Select("id", "role").
+			Where("org_members.org_id = ? AND org_members.user_id = ?", orgId, userId).
+			First(&member).
+			Error
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return member.Role, nil
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostSchedulerStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+// PublishDuePosts publishes up to limit drafts whose publish_at has passed and returns
+// them. SKIP LOCKED lets several API replicas run the scheduler without publishing (and
+// notifying) the same post twice. The deliveries of each post, built by deliveries from
+// the webhooks subscribed to post.published, are queued in the same transaction, a
+// post is never published without its notifications.
+func (s *PostSchedulerStore) PublishDuePosts(ctx context.Context, limit int, deliveries func(entity.Post, []entity.ProjectWebhook) []entity.WebhookDelivery) ([]entity.Post, error) {
+	var posts []entity.Post
+
+	now := time.Now()
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Preload("Project", nil).
+				Where("posts.status = ? AND posts.publish_at <= ?", "draft", now).
+				Order("posts.publish_at ASC").
+				Limit(limit).
+				Find(&posts).
+				Error
+
+			if err != nil || len(posts) == 0 {
+				return err
+			}
+
+			ids := utils.MapSlice(posts, func(p entity.Post) uint {
+				return p.ID
+			})
+
+			err = tx.
+				Model(&entity.Post{}).
+				Where("id IN ?", ids).
+				Updates(map[string]any{
+					"status":       "published",
+					"published_at": now,
+					"updated_at":   now,
+				}).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			for i := range posts {
+				posts[i].Status = "published"
+				posts[i].PublishedAt = &now
+				posts[i].UpdatedAt = &now
+			}
+
+			return enqueuePublished(tx, posts, deliveries)
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// enqueuePublished queues the deliveries of the published posts with tx.
+func enqueuePublished(tx *gorm.DB, posts []entity.Post, deliveries func(entity.Post, []entity.ProjectWebhook) []entity.WebhookDelivery) error {
+	projectIds := utils.MapSlice(posts, func(p entity.Post) uint {
+		return p.ProjectId
+	})
+
+	var webhooks []entity.ProjectWebhook
+
+	err := tx.
+		Where("project_webhooks.project_id IN ? AND project_webhooks.enabled = ? AND ? = ANY(project_webhooks.events)", projectIds, true, entity.WebhookEventPostPublished).
+		Order("project_webhooks.id ASC").
+		Find(&webhooks).
+		Error
+
+	if err != nil {
+		return err
+	}
+
+	var queued []entity.WebhookDelivery
+
+	for _, post := range posts {
+		hooks := utils.FilterSlice(webhooks, func(w entity.ProjectWebhook) bool {
+			return w.ProjectId == post.ProjectId
+		})
+
+		if len(hooks) > 0 {
+			queued = append(queued, deliveries(post, hooks)...)
+		}
+	}
+
+	if len(queued) == 0 {
+		return nil
+	}
+
+	return enqueueDeliveries(tx, queued)
+}
@@ -18,12 +18,24 @@ type PostStore struct {
 }
 
 func (s *PostStore) CreatePost(ctx context.Context, req request.AddPostRequest) (entity.Post, error) {
+	status := req.Status
+
+	if status == "" {
+		status = "draft"
+	}
+
 	post := entity.Post{
 		ProjectId: req.ProjectId,
 		Title:     req.Title,
 		Content:   req.Content,
 		Category:  req.Category,
-		Status:    req.Status,
+		Status:    status,
+		PublishAt: req.PublishAt,
+	}
+
+	if status == "published" {
+		now := time.Now()
+		post.PublishedAt = &now
 	}
 
 	result := s.gormDb.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
@@ -79,3 +91,95 @@ func (s *PostStore) GetPost(ctx context.Context, req request.GetPostRequest) (ut
 
 	return result, nil
 }
+
+func (s *PostStore) GetPostById(ctx context.Context, postId uint) (entity.Post, error) {
+	var post entity.Post
+
+	err := s.gormDb.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Preload("Project", nil).
+			First(&post, postId).
+			Error
+	})
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	return post, nil
+}
+
+// updatePost applies updates to a post, the conditions narrow the update further
+// (e.g. the current status), gorm.ErrRecordNotFound is returned when nothing matched.
+func (s *PostStore) updatePost(ctx context.Context, postId uint, updates map[string]any, conditions ...any) (entity.Post, error) {
+	updates["updated_at"] = time.Now()
+
+	result := s.gormDb.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		query := tx.
+			Model(&entity.Post{}).
+			Where("id = ?", postId)
+
+		if len(conditions) > 0 {
+			query = query.Where(conditions[0], conditions[1:]...)
+		}
+
+		return query.Updates(updates)
+	})
+
+	if result.Error != nil {
+		return entity.Post{}, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.Post{}, gorm.ErrRecordNotFound
+	}
+
+	return s.GetPostById(ctx, postId)
+}
+
+func (s *PostStore) UpdatePost(ctx context.Context, postId uint, req request.UpdatePostRequest) (entity.Post, error) {
+	return s.updatePost(ctx, postId, map[string]any{
+		"title":    req.Title,
+		"content":  req.Content,
+		"category": req.Category,
+	})
+}
+
+func (s *PostStore) PatchPost(ctx context.Context, postId uint, req request.PatchPostRequest) (entity.Post, error) {
+	updates := req.Updates()
+
+	if len(updates) == 0 {
+		return s.GetPostById(ctx, postId)
+	}
+
+	return s.updatePost(ctx, postId, updates)
+}
+
+func (s *PostStore) DeletePost(ctx context.Context, postId uint) error {
+	result := s.gormDb.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.Delete(&entity.Post{}, postId)
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// PublishPost only moves a draft to published, dated now, and drops its schedule,
+// gorm.ErrRecordNotFound is returned when the post does not exist or is already published.
+func (s *PostStore) PublishPost(ctx context.Context, postId uint) (entity.Post, error) {
+	return s.updatePost(ctx, postId, map[string]any{"status": "published", "publish_at": nil, "published_at": time.Now()}, "status = ?", "draft")
+}
+
+// UnpublishPost only moves a published post back to draft, the schedule is dropped so
+// the scheduler doesn't publish it again. gorm.ErrRecordNotFound is returned when the
+// post does not exist or is already a draft.
+func (s *PostStore) UnpublishPost(ctx context.Context, postId uint) (entity.Post, error) {
+	return s.updatePost(ctx, postId, map[string]any{"status": "draft", "publish_at": nil, "published_at": nil}, "status = ?", "published")
+}
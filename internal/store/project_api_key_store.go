@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/apikey"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// lastUsedResolution limits the last_used_at updates of a busy key to one a minute
+const lastUsedResolution = time.Minute
+
+type ProjectApiKeyStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+func (s *ProjectApiKeyStore) GetApiKeys(ctx context.Context, projectId uint) ([]entity.ProjectApiKey, error) {
+	var keys []entity.ProjectApiKey
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("project_api_keys.project_id = ?", projectId).
+			Order("project_api_keys.id DESC").
+			Find(&keys).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// AddApiKey creates a key for the project, the returned key carries the whole key
+// in Key, it can't be read again.
+func (s *ProjectApiKeyStore) AddApiKey(ctx context.Context, projectId uint, userId uint, req request.AddProjectApiKeyRequest) (entity.ProjectApiKey, error) {
+	key, prefix, hash, err := apikey.New()
+
+	if err != nil {
+		return entity.ProjectApiKey{}, err
+	}
+
+	apiKey := entity.ProjectApiKey{
+		ProjectId: projectId,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    req.Scopes,
+		CreatedBy: &userId,
+	}
+
+	err = s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Create(&apiKey).Error
+	})
+
+	if err != nil {
+		return entity.ProjectApiKey{}, err
+	}
+
+	apiKey.Key = key
+
+	return apiKey, nil
+}
+
+func (s *ProjectApiKeyStore) RevokeApiKey(ctx context.Context, projectId uint, keyId uint) error {
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Model(&entity.ProjectApiKey{}).
+			Where("project_api_keys.project_id = ? AND project_api_keys.id = ? AND project_api_keys.revoked_at IS NULL", projectId, keyId).
+			Update("revoked_at", time.Now())
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// AuthenticateApiKey returns the unrevoked key matching key and records its use,
+// gorm.ErrRecordNotFound when there is none.
+func (s *ProjectApiKeyStore) AuthenticateApiKey(ctx context.Context, key string) (entity.ProjectApiKey, error) {
+	prefix, ok := apikey.Prefix(key)
+
+	if !ok {
+		return entity.ProjectApiKey{}, gorm.ErrRecordNotFound
+	}
+
+	var apiKey entity.ProjectApiKey
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("project_api_keys.prefix = ? AND project_api_keys.revoked_at IS NULL", prefix).
+			First(&apiKey).
+			Error
+	})
+
+	if err != nil {
+		return entity.ProjectApiKey{}, err
+	}
+
+	if !apikey.Matches(key, apiKey.KeyHash) {
+		return entity.ProjectApiKey{}, gorm.ErrRecordNotFound
+	}
+
+	now := time.Now()
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedResolution {
+		err = s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+			return tx.
+				Model(&entity.ProjectApiKey{}).
+				Where("project_api_keys.id = ?", apiKey.ID).
+				Update("last_used_at", now).
+				Error
+		})
+
+		// a missed last_used_at update doesn't fail the request
+		if err != nil {
+			s.logger.Warn("⚠️ Failed to update api key last used", zap.Uint("ApiKeyId", apiKey.ID), zap.Error(err))
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,178 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ProjectMemberStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+func (s *ProjectMemberStore) GetMembers(ctx context.Context, projectId uint) ([]entity.ProjectMember, error) {
+	var members []entity.ProjectMember
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("project_members.project_id = ?", projectId).
+			Order("project_members.id ASC").
+			Find(&members).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddMember invites req.Email to the project, the member is linked to the user right
+// away when the email is already registered and verified. Otherwise the invitation
+// waits for AcceptInvites.
+func (s *ProjectMemberStore) AddMember(ctx context.Context, projectId uint, req request.AddProjectMemberRequest) (entity.ProjectMember, error) {
+	member := entity.ProjectMember{
+		ProjectId: projectId,
+		Email:     req.Email,
+		Role:      req.Role,
+	}
+
+	var user entity.User
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Select("id").
+			Where("LOWER(users.email) = LOWER(?) AND users.email_verified_at IS NOT NULL", req.Email).
+			First(&user).
+			Error
+	})
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.ProjectMember{}, err
+	}
+
+	if err == nil {
+		member.UserId = &user.ID
+	}
+
+	err = s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Create(&member).Error
+	})
+
+	if err != nil {
+		return entity.ProjectMember{}, err
+	}
+
+	return member, nil
+}
+
+func (s *ProjectMemberStore) UpdateMember(ctx context.Context, projectId uint, memberId uint, req request.UpdateProjectMemberRequest) (entity.ProjectMember, error) {
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Model(&entity.ProjectMember{}).
+			Where("project_members.project_id = ? AND project_members.id = ?", projectId, memberId).
+			Updates(map[string]any{
+				"role":       req.Role,
+				"updated_at": time.Now(),
+			})
+	})
+
+	if result.Error != nil {
+		return entity.ProjectMember{}, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.ProjectMember{}, gorm.ErrRecordNotFound
+	}
+
+	var member entity.ProjectMember
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.First(&member, memberId).Error
+	})
+
+	if err != nil {
+		return entity.ProjectMember{}, err
+	}
+
+	return member, nil
+}
+
+func (s *ProjectMemberStore) DeleteMember(ctx context.Context, projectId uint, memberId uint) error {
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Where("project_members.project_id = ?", projectId).
+			Delete(&entity.ProjectMember{}, memberId)
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetMemberRole returns the role of a user in a project, the highest of their project
+// role and their role in the project's org. gorm.ErrRecordNotFound is returned when
+// the user is neither a member of the project nor of its org.
+func (s *ProjectMemberStore) GetMemberRole(ctx context.Context, projectId uint, userId uint) (string, error) {
+	var roles []string
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Raw(`
+			SELECT project_members.role FROM project_members
+			WHERE project_members.project_id = ? AND project_members.user_id = ?
+			UNION ALL
+			SELECT org_members.role FROM org_members
+			INNER JOIN projects ON projects.org_id = org_members.org_id
+			WHERE projects.id = ? AND org_members.user_id = ?
+			`, projectId, userId, projectId, userId).
+			Scan(&roles).
+			Error
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(roles) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	return entity.HighestProjectRole(roles), nil
+}
+
+// AcceptInvites links the pending invitations of email to the user, it returns the
+// number of projects joined. Nothing is linked unless email is the verified email of
+// the user.
+func (s *ProjectMemberStore) AcceptInvites(ctx context.Context, userId uint, email string) (int64, error) {
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Model(&entity.ProjectMember{}).
+			Where("project_members.user_id IS NULL AND LOWER(project_members.email) = LOWER(?)", email).
+			Where("EXISTS (SELECT 1 FROM users WHERE users.id = ? AND LOWER(users.email) = LOWER(?) AND users.email_verified_at IS NOT NULL)", userId, email).
+			Updates(map[string]any{
+				"user_id":    userId,
+				"updated_at": time.Now(),
+			})
+	})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
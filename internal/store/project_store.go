@@ -13,6 +13,7 @@ import (
 	"github.com/ariefzainuri96/go-logstream/cmd/api/middleware"
 	"github.com/ariefzainuri96/go-logstream/internal/db"
 	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"github.com/ariefzainuri96/go-logstream/pkg/webhooksig"
 	"gorm.io/gorm"
 )
 
@@ -63,19 +64,72 @@ func (s *ProjectStore) AddProject(ctx context.Context, userId uint, req request.
 		return entity.Project{}, errors.New("Slug sudah terdaftar")
 	}
 
+	webhookSecret, err := webhooksig.NewSecret()
+
+	if err != nil {
+		return entity.Project{}, err
+	}
+
+	// allowed_origins is NOT NULL, a nil array would be inserted as NULL
+	allowedOrigins := req.AllowedOrigins
+
+	if allowedOrigins == nil {
+		allowedOrigins = []string{}
+	}
+
+	// the creator becomes the owner of the project
+	var user entity.User
+
+	err = s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Select("id", "email").
+			First(&user, userId).
+			Error
+	})
+
+	if err != nil {
+		return entity.Project{}, err
+	}
+
 	project := entity.Project{
-		UserId:     userId,
-		Name:       req.Name,
-		Slug:       req.Slug,
-		WebhookUrl: req.WebhookUrl,
+		OrgId:          req.OrgId,
+		Name:           req.Name,
+		Slug:           req.Slug,
+		WebhookSecret:  webhookSecret,
+		AllowedOrigins: allowedOrigins,
+		PageTitle:      req.PageTitle,
+		LogoUrl:        req.LogoUrl,
+		AccentColor:    req.AccentColor,
+		Members: []entity.ProjectMember{
+			{
+				UserId: &userId,
+				Email:  user.Email,
+				Role:   entity.ProjectRoleOwner,
+			},
+		},
+	}
+
+	if req.WebhookUrl != "" {
+		project.Webhooks = []entity.ProjectWebhook{
+			newProjectWebhook(0, request.AddProjectWebhookRequest{
+				Url:      req.WebhookUrl,
+				Provider: req.WebhookProvider,
+				Events:   entity.WebhookEvents,
+			}),
+		}
 	}
 
+	// gorm creates the project, its owner and first webhook in one transaction
 	err = s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
 		return tx.
 			Create(&project).
 			Error
 	})
 
+	if err != nil {
+		return entity.Project{}, err
+	}
+
 	return project, nil
 }
 
@@ -85,7 +139,13 @@ func (s *ProjectStore) GetProject(ctx context.Context, userId uint, req request.
 	ctx, cancel := context.WithTimeout(ctx, 15 * time.Second)
 	defer cancel()
 
-	query := s.db.GormDb.WithContext(ctx).Where("user_id = ?", userId).Find(&projects)	
+	// projects of the user's orgs and projects the user was invited to, whatever the role
+	query := s.db.GormDb.WithContext(ctx).
+		Where(`(
+			projects.org_id IN (SELECT org_id FROM org_members WHERE user_id = ?)
+			OR projects.id IN (SELECT project_id FROM project_members WHERE user_id = ?)
+		)`, userId, userId).
+		Find(&projects)
 
 	var searchAllQuery string
 
@@ -105,6 +165,20 @@ func (s *ProjectStore) GetProject(ctx context.Context, userId uint, req request.
 	return result, nil
 }
 
+func (s *ProjectStore) GetProjectById(ctx context.Context, projectId uint) (entity.Project, error) {
+	var project entity.Project
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.First(&project, projectId).Error
+	})
+
+	if err != nil {
+		return entity.Project{}, err
+	}
+
+	return project, nil
+}
+
 func (s *ProjectStore) DeleteProject(ctx context.Context, projectId uint) error {
 	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
 		return tx.
@@ -120,10 +194,19 @@ func (s *ProjectStore) DeleteProject(ctx context.Context, projectId uint) error
 }
 
 func (s *ProjectStore) UpdateProject(ctx context.Context, projectId uint, req request.AddProjectRequest) (entity.Project, error) {
-	project := entity.Project{		
-		Name:       req.Name,
-		Slug:       req.Slug,
-		WebhookUrl: req.WebhookUrl,
+	// webhooks are managed with the /{id}/webhooks endpoints, so only name, slug,
+	// allowed origins and page settings are updated
+	project := entity.Project{
+		Name:        req.Name,
+		Slug:        req.Slug,
+		PageTitle:   req.PageTitle,
+		LogoUrl:     req.LogoUrl,
+		AccentColor: req.AccentColor,
+	}
+
+	// an empty (not nil) list is still updated, it clears the origins
+	if req.AllowedOrigins != nil {
+		project.AllowedOrigins = req.AllowedOrigins
 	}
 
 	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
@@ -147,3 +230,48 @@ func (s *ProjectStore) UpdateProject(ctx context.Context, projectId uint, req re
 
 	return project, nil
 }
+
+func (s *ProjectStore) GetWebhookSecret(ctx context.Context, projectId uint) (string, error) {
+	var project entity.Project
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Select("id", "webhook_secret").
+			First(&project, projectId).
+			Error
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return project.WebhookSecret, nil
+}
+
+func (s *ProjectStore) RotateWebhookSecret(ctx context.Context, projectId uint) (string, error) {
+	webhookSecret, err := webhooksig.NewSecret()
+
+	if err != nil {
+		return "", err
+	}
+
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Model(&entity.Project{}).
+			Where("id = ?", projectId).
+			Updates(map[string]any{
+				"webhook_secret": webhookSecret,
+				"updated_at":     time.Now(),
+			})
+	})
+
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	return webhookSecret, nil
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ProjectWebhookStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+func newProjectWebhook(projectId uint, req request.AddProjectWebhookRequest) entity.ProjectWebhook {
+	provider := req.Provider
+
+	if provider == "" {
+		provider = "generic"
+	}
+
+	enabled := true
+
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	return entity.ProjectWebhook{
+		ProjectId:       projectId,
+		Url:             req.Url,
+		Provider:        provider,
+		Enabled:         enabled,
+		Events:          req.Events,
+		PayloadTemplate: req.PayloadTemplate,
+	}
+}
+
+func (s *ProjectWebhookStore) GetWebhooks(ctx context.Context, projectId uint) ([]entity.ProjectWebhook, error) {
+	var webhooks []entity.ProjectWebhook
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("project_webhooks.project_id = ?", projectId).
+			Order("project_webhooks.id ASC").
+			Find(&webhooks).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (s *ProjectWebhookStore) GetWebhook(ctx context.Context, projectId uint, webhookId uint) (entity.ProjectWebhook, error) {
+	var webhook entity.ProjectWebhook
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("project_webhooks.project_id = ?", projectId).
+			First(&webhook, webhookId).
+			Error
+	})
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (s *ProjectWebhookStore) AddWebhook(ctx context.Context, projectId uint, req request.AddProjectWebhookRequest) (entity.ProjectWebhook, error) {
+	webhook := newProjectWebhook(projectId, req)
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Create(&webhook).Error
+	})
+
+	if err != nil {
+		return entity.ProjectWebhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (s *ProjectWebhookStore) UpdateWebhook(ctx context.Context, projectId uint, webhookId uint, req request.AddProjectWebhookRequest) (entity.ProjectWebhook, error) {
+	webhook := newProjectWebhook(projectId, req)
+
+	// use a map so enabled=false is persisted too
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Model(&entity.ProjectWebhook{}).
+			Where("id = ? AND project_id = ?", webhookId, projectId).
+			Updates(map[string]any{
+				"url":              webhook.Url,
+				"provider":         webhook.Provider,
+				"enabled":          webhook.Enabled,
+				"events":           webhook.Events,
+				"payload_template": webhook.PayloadTemplate,
+			})
+	})
+
+	if result.Error != nil {
+		return entity.ProjectWebhook{}, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.ProjectWebhook{}, gorm.ErrRecordNotFound
+	}
+
+	return s.GetWebhook(ctx, projectId, webhookId)
+}
+
+func (s *ProjectWebhookStore) DeleteWebhook(ctx context.Context, projectId uint, webhookId uint) error {
+	result := s.db.ExecWithTimeoutVal(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.
+			Where("project_id = ?", projectId).
+			Delete(&entity.ProjectWebhook{}, webhookId)
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetSubscribedWebhooks returns the enabled webhooks of a project subscribed to event.
+func (s *ProjectWebhookStore) GetSubscribedWebhooks(ctx context.Context, projectId uint, event string) ([]entity.ProjectWebhook, error) {
+	var webhooks []entity.ProjectWebhook
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("project_webhooks.project_id = ? AND project_webhooks.enabled = ? AND ? = ANY(project_webhooks.events)", projectId, true, event).
+			Order("project_webhooks.id ASC").
+			Find(&webhooks).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
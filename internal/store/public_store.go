@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PublicStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+// GetPublicProject finds a project by slug (idx_projects_slug).
+func (s *PublicStore) GetPublicProject(ctx context.Context, slug string) (entity.Project, error) {
+	var project entity.Project
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("projects.slug = ?", slug).
+			First(&project).
+			Error
+	})
+
+	if err != nil {
+		return entity.Project{}, err
+	}
+
+	return project, nil
+}
+
+// publishedPosts scopes a query to the published posts of a project, drafts
+// (including scheduled ones) are never visible publicly.
+func publishedPosts(projectId uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Model(&entity.Post{}).
+			Where("posts.project_id = ? AND posts.status = ?", projectId, "published")
+	}
+}
+
+func (s *PublicStore) GetPublicPosts(ctx context.Context, projectId uint, req request.GetPublicPostRequest) (utils.PaginateResult[entity.Post], error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	query := s.db.GormDb.WithContext(ctx).
+		Scopes(publishedPosts(projectId))
+
+	if req.Category != "" {
+		query = query.Where("posts.category = ?", req.Category)
+	}
+
+	result := utils.ApplyPagination[entity.Post](query, req.Pagination(), "")
+
+	if result.Error != nil {
+		return utils.PaginateResult[entity.Post]{}, result.Error
+	}
+
+	return result, nil
+}
+
+// CountPublicPostsSince counts the published posts of a project published after since,
+// a zero since counts all of them.
+func (s *PublicStore) CountPublicPostsSince(ctx context.Context, projectId uint, since time.Time) (int64, error) {
+	var count int64
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		query := tx.Scopes(publishedPosts(projectId))
+
+		if !since.IsZero() {
+			query = query.Where("posts.published_at > ?", since)
+		}
+
+		return query.Count(&count).Error
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *PublicStore) GetPublicPost(ctx context.Context, projectId uint, postId uint) (entity.Post, error) {
+	var post entity.Post
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Scopes(publishedPosts(projectId)).
+			First(&post, postId).
+			Error
+	})
+
+	if err != nil {
+		return entity.Post{}, err
+	}
+
+	return post, nil
+}
+
+// GetPublicCategories returns the distinct categories of the published posts of a
+// project, sorted.
+func (s *PublicStore) GetPublicCategories(ctx context.Context, projectId uint) ([]string, error) {
+	var categories []string
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Scopes(publishedPosts(projectId)).
+			Where("posts.category <> ?", "").
+			Distinct("posts.category").
+			Order("posts.category ASC").
+			Pluck("posts.category", &categories).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
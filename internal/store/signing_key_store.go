@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"github.com/ariefzainuri96/go-logstream/internal/interfaces"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// signingKeysLockId is the advisory lock serializing the key rotations of the replicas.
+const signingKeysLockId int64 = 0x6a77746b657973 // "jwtkeys"
+
+// SigningKeyStore keeps the JWT signing keys in Postgres, so replicas share them.
+type SigningKeyStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+func (s *SigningKeyStore) GetSigningKeys(ctx context.Context) ([]entity.SigningKey, error) {
+	var keys []entity.SigningKey
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Order("signing_keys.activates_at ASC").
+			Find(&keys).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *SigningKeyStore) AddSigningKey(ctx context.Context, key entity.SigningKey) error {
+	return s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Create(&key).Error
+	})
+}
+
+func (s *SigningKeyStore) DeleteSigningKeys(ctx context.Context, kids []string) error {
+	return s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("signing_keys.kid IN ?", kids).
+			Delete(&entity.SigningKey{}).
+			Error
+	})
+}
+
+// LockSigningKeys runs fn in a transaction holding an advisory lock, replicas starting
+// together would each add a first key otherwise. The lock is released on commit.
+func (s *SigningKeyStore) LockSigningKeys(ctx context.Context, fn func(interfaces.ISigningKey) error) error {
+	return s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", signingKeysLockId).Error; err != nil {
+				return err
+			}
+
+			return fn(&SigningKeyStore{db: &db.GormDB{GormDb: tx}, logger: s.logger})
+		})
+	})
+}
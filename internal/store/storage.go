@@ -3,19 +3,46 @@ package store
 import (
 	db "github.com/ariefzainuri96/go-logstream/internal/db"
 	"github.com/ariefzainuri96/go-logstream/internal/interfaces"
+	"github.com/ariefzainuri96/go-logstream/internal/jwtkeys"
 	"go.uber.org/zap"
 )
 
 type Storage struct {
-	IAuth    interfaces.IAuth
-	IProject interfaces.IProject
-	IPost    interfaces.IPost
+	IAuth            interfaces.IAuth
+	IProject         interfaces.IProject
+	IPost            interfaces.IPost
+	IWebhookQueue    interfaces.IWebhookQueue
+	IWebhookDelivery interfaces.IWebhookDelivery
+	IProjectWebhook  interfaces.IProjectWebhook
+	IPostScheduler   interfaces.IPostScheduler
+	IPublic          interfaces.IPublic
+	IProjectMember   interfaces.IProjectMember
+	IOrganization    interfaces.IOrganization
+	IProjectApiKey   interfaces.IProjectApiKey
+	ILoginAttempt    interfaces.ILoginAttempt
+	ISigningKey      interfaces.ISigningKey
+	// signs and verifies the JWTs with the keys of ISigningKey
+	Keys *jwtkeys.KeyManager
 }
 
-func NewStorage(gorm *db.GormDB, logger *zap.Logger) Storage {
+func NewStorage(gorm *db.GormDB, logger *zap.Logger, keyCfg jwtkeys.Config) Storage {
+	signingKeys := &SigningKeyStore{gorm, logger}
+	keys := jwtkeys.New(signingKeys, keyCfg, logger)
+
 	return Storage{
-		IAuth:    &AuthStore{gorm},
-		IProject: &ProjectStore{gorm, logger},
-		IPost:    &PostStore{gorm, logger},
+		IAuth:            &AuthStore{gorm, keys},
+		IProject:         &ProjectStore{gorm, logger},
+		IPost:            &PostStore{gorm, logger},
+		IWebhookQueue:    &WebhookQueueStore{gorm, logger},
+		IWebhookDelivery: &WebhookDeliveryStore{gorm, logger},
+		IProjectWebhook:  &ProjectWebhookStore{gorm, logger},
+		IPostScheduler:   &PostSchedulerStore{gorm, logger},
+		IPublic:          &PublicStore{gorm, logger},
+		IProjectMember:   &ProjectMemberStore{gorm, logger},
+		IOrganization:    &OrganizationStore{gorm, logger},
+		IProjectApiKey:   &ProjectApiKeyStore{gorm, logger},
+		ILoginAttempt:    &LoginAttemptStore{gorm, logger},
+		ISigningKey:      signingKeys,
+		Keys:             keys,
 	}
 }
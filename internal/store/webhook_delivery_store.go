@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/request"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrWebhookGone is returned by RedeliverDelivery when the webhook of the delivery
+	// was deleted.
+	ErrWebhookGone = errors.New("the webhook of the delivery was deleted")
+	// ErrWebhookDisabled is returned by RedeliverDelivery when the webhook of the
+	// delivery is disabled.
+	ErrWebhookDisabled = errors.New("the webhook of the delivery is disabled")
+)
+
+type WebhookDeliveryStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+func preloadAttemptLogs(db *gorm.DB) *gorm.DB {
+	return db.Order("webhook_delivery_attempts.attempt ASC")
+}
+
+func (s *WebhookDeliveryStore) GetDeliveries(ctx context.Context, projectId uint, req request.GetWebhookDeliveryRequest) (utils.PaginateResult[entity.WebhookDelivery], error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	query := s.db.GormDb.WithContext(ctx).
+		Model(&entity.WebhookDelivery{}).
+		Where("webhook_deliveries.project_id = ?", projectId).
+		Preload("AttemptLogs", preloadAttemptLogs)
+
+	if req.Status != "" {
+		query = query.Where("webhook_deliveries.status = ?", req.Status)
+	}
+
+	var searchAllQuery string
+
+	if req.SearchAll != "" {
+		searchAllQuery = `
+		webhook_deliveries.event ILIKE ?
+		OR webhook_deliveries.url ILIKE ?
+		OR webhook_deliveries.last_error ILIKE ?
+		`
+	}
+
+	result := utils.ApplyPagination[entity.WebhookDelivery](query, req.PaginationRequest, searchAllQuery)
+
+	if result.Error != nil {
+		return utils.PaginateResult[entity.WebhookDelivery]{}, result.Error
+	}
+
+	return result, nil
+}
+
+func (s *WebhookDeliveryStore) GetDelivery(ctx context.Context, projectId uint, deliveryId uint) (entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Preload("AttemptLogs", preloadAttemptLogs).
+			Where("webhook_deliveries.project_id = ?", projectId).
+			First(&delivery, deliveryId).
+			Error
+	})
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+// RedeliverDelivery queues a fresh copy of a previous delivery, so the original
+// attempt log is kept intact and the retry budget starts over. The copy is sent to
+// the current url of the webhook, it fails with ErrWebhookGone or ErrWebhookDisabled
+// when the webhook can't receive it anymore.
+func (s *WebhookDeliveryStore) RedeliverDelivery(ctx context.Context, projectId uint, deliveryId uint) (entity.WebhookDelivery, error) {
+	original, err := s.GetDelivery(ctx, projectId, deliveryId)
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	// webhook_id is set to null when the webhook is deleted
+	if original.WebhookId == nil {
+		return entity.WebhookDelivery{}, ErrWebhookGone
+	}
+
+	var hook entity.ProjectWebhook
+
+	err = s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Where("project_webhooks.project_id = ?", projectId).
+			First(&hook, *original.WebhookId).
+			Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.WebhookDelivery{}, ErrWebhookGone
+	}
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	if !hook.Enabled {
+		return entity.WebhookDelivery{}, ErrWebhookDisabled
+	}
+
+	webhookId := hook.ID
+
+	delivery := entity.WebhookDelivery{
+		ProjectId:     original.ProjectId,
+		WebhookId:     &webhookId,
+		PostId:        original.PostId,
+		Event:         original.Event,
+		Provider:      hook.Provider,
+		Url:           hook.Url,
+		Payload:       original.Payload,
+		Status:        entity.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+
+	err = s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Create(&delivery).Error
+	})
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
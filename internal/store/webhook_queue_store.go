@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/ariefzainuri96/go-logstream/internal/db"
+	"github.com/ariefzainuri96/go-logstream/internal/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookQueueStore struct {
+	db     *db.GormDB
+	logger *zap.Logger
+}
+
+// enqueueDeliveries inserts deliveries as pending with tx.
+func enqueueDeliveries(tx *gorm.DB, deliveries []entity.WebhookDelivery) error {
+	now := time.Now()
+
+	for i := range deliveries {
+		deliveries[i].Status = entity.WebhookDeliveryPending
+		deliveries[i].Attempts = 0
+
+		if deliveries[i].NextAttemptAt.IsZero() {
+			deliveries[i].NextAttemptAt = now
+		}
+	}
+
+	return tx.Create(&deliveries).Error
+}
+
+func (s *WebhookQueueStore) EnqueueDelivery(ctx context.Context, delivery entity.WebhookDelivery) (entity.WebhookDelivery, error) {
+	deliveries := []entity.WebhookDelivery{delivery}
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return enqueueDeliveries(tx, deliveries)
+	})
+
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	return deliveries[0], nil
+}
+
+// ClaimDueDeliveries locks up to limit deliveries that are due (or whose previous
+// claim is older than staleAfter, e.g. the replica crashed mid-send) and marks them
+// as processing. SKIP LOCKED lets several API replicas poll the same table safely.
+func (s *WebhookQueueStore) ClaimDueDeliveries(ctx context.Context, limit int, staleAfter time.Duration) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+
+	now := time.Now()
+
+	err := s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Preload("Project", nil).
+				Where("(webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?) OR (webhook_deliveries.status = ? AND webhook_deliveries.updated_at <= ?)",
+					entity.WebhookDeliveryPending, now, entity.WebhookDeliveryProcessing, now.Add(-staleAfter)).
+				Order("webhook_deliveries.next_attempt_at ASC").
+				Limit(limit).
+				Find(&deliveries).
+				Error
+
+			if err != nil || len(deliveries) == 0 {
+				return err
+			}
+
+			ids := utils.MapSlice(deliveries, func(d entity.WebhookDelivery) uint {
+				return d.ID
+			})
+
+			return tx.
+				Model(&entity.WebhookDelivery{}).
+				Where("id IN ?", ids).
+				Updates(map[string]any{
+					"status":     entity.WebhookDeliveryProcessing,
+					"updated_at": now,
+				}).
+				Error
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range deliveries {
+		deliveries[i].Status = entity.WebhookDeliveryProcessing
+	}
+
+	return deliveries, nil
+}
+
+// SaveDeliveryResult updates the delivery state and appends the attempt to the
+// delivery log in a single transaction.
+func (s *WebhookQueueStore) SaveDeliveryResult(ctx context.Context, delivery entity.WebhookDelivery, attempt entity.WebhookDeliveryAttempt) error {
+	return s.db.ExecWithTimeoutErr(ctx, func(tx *gorm.DB) error {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&attempt).Error; err != nil {
+				return err
+			}
+
+			// use a map so zero values (e.g. clearing last_error) are persisted too
+			return tx.
+				Model(&entity.WebhookDelivery{}).
+				Where("id = ?", delivery.ID).
+				Updates(map[string]any{
+					"status":          delivery.Status,
+					"attempts":        delivery.Attempts,
+					"next_attempt_at": delivery.NextAttemptAt,
+					"last_error":      delivery.LastError,
+					"delivered_at":    delivery.DeliveredAt,
+					"updated_at":      time.Now(),
+				}).
+				Error
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+)
+
+func init() {
+	Register("discord", discordFormatter{})
+}
+
+// discord rejects embed descriptions longer than this
+const discordMaxDescriptionLength = 4096
+
+type discordFormatter struct{}
+
+// discordDescription keeps the Markdown of content, which Discord renders, unless
+// it's too long and has to be cut as plain text.
+func discordDescription(content string) string {
+	if utf8.RuneCountInString(content) <= discordMaxDescriptionLength {
+		return content
+	}
+
+	return markdown.Excerpt(content, discordMaxDescriptionLength)
+}
+
+func (discordFormatter) Format(msg Message) (Payload, error) {
+	post := msg.Post
+
+	// Discord expects "content" or "embeds"
+	return Payload{
+		"username": "LogStream", // Custom bot name
+		"embeds": []map[string]interface{}{
+			{
+				"title":       post.Title,
+				"description": discordDescription(post.Content),
+				"color":       5814783, // A nice blue color (Decimal value)
+				"fields": []map[string]interface{}{
+					{
+						"name":   "Category",
+						"value":  post.Category,
+						"inline": true,
+					},
+					{
+						"name":   "Status",
+						"value":  post.Status,
+						"inline": true,
+					},
+					{
+						"name":   "Post ID",
+						"value":  fmt.Sprintf("%d", post.ID),
+						"inline": true,
+					},
+				},
+				"footer": map[string]string{
+					"text": "Sent via LogStream",
+				},
+				"timestamp": time.Now().Format(time.RFC3339),
+			},
+		},
+	}, nil
+}
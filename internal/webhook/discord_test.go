@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDiscordDescription(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantLen int
+		cut     bool
+	}{
+		{"short keeps markdown", "**bold**", 8, false},
+		{"exact limit keeps markdown", strings.Repeat("a", discordMaxDescriptionLength), discordMaxDescriptionLength, false},
+		{"too long is cut", strings.Repeat("**abcdef** ", 1000), discordMaxDescriptionLength, true},
+		{"multibyte runes", strings.Repeat("日本", discordMaxDescriptionLength), discordMaxDescriptionLength, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := discordDescription(tt.content)
+
+			if n := utf8.RuneCountInString(got); n > tt.wantLen || (!tt.cut && n != tt.wantLen) {
+				t.Fatalf("got %d runes, want %d", n, tt.wantLen)
+			}
+
+			if tt.cut != strings.HasSuffix(got, "…") {
+				t.Fatalf("cut = %v, got %q...", tt.cut, got[:20])
+			}
+
+			if tt.cut && strings.Contains(got, "**") {
+				t.Fatal("a cut description must be plain text")
+			}
+
+			if !utf8.ValidString(got) {
+				t.Fatal("invalid utf-8")
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultProvider is used for webhooks without a provider, or with one that is
+// not registered anymore.
+const DefaultProvider = "generic"
+
+// ErrInvalidUrl is returned by formatters for a webhook url missing what the provider
+// needs, a configuration error of the user.
+var ErrInvalidUrl = errors.New("invalid webhook url")
+
+type Payload map[string]interface{}
+
+// Message is everything a formatter needs to build the body of one webhook request.
+type Message struct {
+	Event string
+	Post  entity.Post
+	// target url, some providers (e.g. telegram) carry parameters in it
+	Url string
+}
+
+// WebhookFormatter turns a post event into the JSON body expected by a provider.
+type WebhookFormatter interface {
+	Format(Message) (Payload, error)
+}
+
+// UrlValidator is implemented by the formatters of providers that need something in
+// the webhook url, e.g. the telegram chat_id.
+type UrlValidator interface {
+	ValidateUrl(string) error
+}
+
+var (
+	mu         sync.RWMutex
+	formatters = map[string]WebhookFormatter{}
+)
+
+// Register makes a formatter available under provider, replacing any previous one.
+func Register(provider string, formatter WebhookFormatter) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	formatters[strings.ToLower(provider)] = formatter
+}
+
+func Lookup(provider string) (WebhookFormatter, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	formatter, ok := formatters[strings.ToLower(provider)]
+	return formatter, ok
+}
+
+func IsProvider(provider string) bool {
+	_, ok := Lookup(provider)
+	return ok
+}
+
+// Providers returns the registered provider names, sorted.
+func Providers() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	providers := make([]string, 0, len(formatters))
+	for provider := range formatters {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	return providers
+}
+
+// Format builds the payload of msg for provider, falling back to DefaultProvider.
+func Format(provider string, msg Message) (Payload, error) {
+	formatter, ok := Lookup(provider)
+
+	if !ok {
+		formatter, ok = Lookup(DefaultProvider)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("no webhook formatter registered for %q", provider)
+	}
+
+	return formatter.Format(msg)
+}
+
+// ValidateUrl returns an ErrInvalidUrl error when rawUrl lacks what provider needs, so
+// the webhook is refused when it is saved instead of failing every delivery.
+func ValidateUrl(provider string, rawUrl string) error {
+	formatter, ok := Lookup(provider)
+
+	if !ok {
+		formatter, ok = Lookup(DefaultProvider)
+	}
+
+	if validator, isValidator := formatter.(UrlValidator); ok && isValidator {
+		return validator.ValidateUrl(rawUrl)
+	}
+
+	return nil
+}
+
+// ValidateProvider is the "webhook_provider" validator tag, it accepts registered providers.
+func ValidateProvider(fl validator.FieldLevel) bool {
+	return IsProvider(fl.Field().String())
+}
+
+// headline is the human readable title of event used by chat providers.
+func headline(event string) string {
+	switch event {
+	case entity.WebhookEventPostUpdated:
+		return "Updated"
+	case entity.WebhookEventPostUnpublished:
+		return "Unpublished"
+	case entity.WebhookEventPostDeleted:
+		return "Removed"
+	default:
+		return "New Update"
+	}
+}
@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateUrl(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		url      string
+		wantErr  bool
+	}{
+		{"telegram with chat_id", "telegram", "https://api.telegram.org/bot1:abc/sendMessage?chat_id=42", false},
+		{"telegram without chat_id", "telegram", "https://api.telegram.org/bot1:abc/sendMessage", true},
+		{"telegram provider is case insensitive", "Telegram", "https://api.telegram.org/bot1:abc/sendMessage", true},
+		{"generic needs nothing", "generic", "https://example.com/hook", false},
+		{"no provider is generic", "", "https://example.com/hook", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUrl(tt.provider, tt.url)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got %v, want error %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrInvalidUrl) {
+				t.Fatalf("got %v, want %v", err, ErrInvalidUrl)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"strings"
+
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+)
+
+func init() {
+	Register(DefaultProvider, genericFormatter{})
+}
+
+type genericFormatter struct{}
+
+func (genericFormatter) Format(msg Message) (Payload, error) {
+	post := msg.Post
+
+	// Send the raw data for custom integrations (Zapier, n8n, custom backends)
+	return Payload{
+		"event": strings.ReplaceAll(msg.Event, ".", "_"), // post_created, post_published, ...
+		"data": map[string]interface{}{
+			"id":           post.ID,
+			"title":        post.Title,
+			"content":      post.Content,
+			"content_html": markdown.ToHTML(post.Content),
+			"category":     post.Category,
+			"created_at":   post.CreatedAt,
+		},
+	}, nil
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+)
+
+func init() {
+	Register("google_chat", googleChatFormatter{})
+}
+
+type googleChatFormatter struct{}
+
+func (googleChatFormatter) Format(msg Message) (Payload, error) {
+	post := msg.Post
+
+	// "text" is the notification fallback, the card is what is rendered in the space
+	return Payload{
+		"text": fmt.Sprintf("%s: %s", headline(msg.Event), post.Title),
+		"cardsV2": []map[string]interface{}{
+			{
+				"cardId": fmt.Sprintf("post-%d", post.ID),
+				"card": map[string]interface{}{
+					"header": map[string]string{
+						"title":    post.Title,
+						"subtitle": fmt.Sprintf("%s · %s · %s", headline(msg.Event), post.Category, post.Status),
+					},
+					"sections": []map[string]interface{}{
+						{
+							"widgets": []map[string]interface{}{
+								{
+									"textParagraph": map[string]string{
+										"text": html.EscapeString(markdown.PlainText(post.Content)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
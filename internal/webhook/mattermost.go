@@ -0,0 +1,19 @@
+package webhook
+
+import "fmt"
+
+func init() {
+	Register("mattermost", mattermostFormatter{})
+}
+
+type mattermostFormatter struct{}
+
+func (mattermostFormatter) Format(msg Message) (Payload, error) {
+	post := msg.Post
+
+	// Mattermost incoming webhooks render "text" as markdown
+	return Payload{
+		"username": "LogStream",
+		"text":     fmt.Sprintf("#### %s: %s\n**Category:** %s | **Status:** %s\n\n%s", headline(msg.Event), post.Title, post.Category, post.Status, post.Content),
+	}, nil
+}
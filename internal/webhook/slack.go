@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+)
+
+func init() {
+	Register("slack", slackFormatter{})
+}
+
+type slackFormatter struct{}
+
+func (slackFormatter) Format(msg Message) (Payload, error) {
+	post := msg.Post
+
+	// Slack expects "text"
+	return Payload{
+		"text": fmt.Sprintf("*%s: %s*\nCategory: %s\n\n%s", headline(msg.Event), markdown.EscapeSlack(post.Title), markdown.EscapeSlack(post.Category), markdown.ToSlack(post.Content)),
+	}, nil
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+)
+
+func TestSlackEscapesTitleAndCategory(t *testing.T) {
+	payload, err := slackFormatter{}.Format(Message{
+		Event: entity.WebhookEventPostCreated,
+		Post: entity.Post{
+			Title:    "<!channel> R&D",
+			Category: "<@U123>",
+			Content:  "body",
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := payload["text"].(string)
+
+	if want := "*New Update: &lt;!channel&gt; R&amp;D*\nCategory: &lt;@U123&gt;\n\nbody"; text != want {
+		t.Fatalf("got %q, want %q", text, want)
+	}
+
+	if strings.Contains(text, "<") {
+		t.Fatal("a mention got through")
+	}
+}
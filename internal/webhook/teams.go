@@ -0,0 +1,56 @@
+package webhook
+
+func init() {
+	Register("teams", teamsFormatter{})
+}
+
+type teamsFormatter struct{}
+
+// Format builds an Adaptive Card message, accepted by Teams incoming webhooks and
+// the "Post to a channel when a webhook request is received" workflow.
+func (teamsFormatter) Format(msg Message) (Payload, error) {
+	post := msg.Post
+
+	return Payload{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"contentUrl":  nil,
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []map[string]interface{}{
+						{
+							"type":     "TextBlock",
+							"text":     headline(msg.Event),
+							"size":     "Small",
+							"weight":   "Lighter",
+							"isSubtle": true,
+						},
+						{
+							"type":   "TextBlock",
+							"text":   post.Title,
+							"size":   "Medium",
+							"weight": "Bolder",
+							"wrap":   true,
+						},
+						{
+							"type": "TextBlock",
+							"text": post.Content,
+							"wrap": true,
+						},
+						{
+							"type": "FactSet",
+							"facts": []map[string]string{
+								{"title": "Category", "value": post.Category},
+								{"title": "Status", "value": post.Status},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
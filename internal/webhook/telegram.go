@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+)
+
+// telegram rejects messages longer than this
+const telegramMaxMessageLength = 4096
+
+func init() {
+	Register("telegram", telegramFormatter{})
+}
+
+type telegramFormatter struct{}
+
+// chatId returns the chat_id of a webhook url, expected to be
+// https://api.telegram.org/bot<token>/sendMessage?chat_id=<chat id>
+func chatId(rawUrl string) (string, error) {
+	target, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidUrl, err)
+	}
+
+	id := target.Query().Get("chat_id")
+
+	if id == "" {
+		return "", fmt.Errorf("%w: telegram webhook url must contain the chat_id query parameter", ErrInvalidUrl)
+	}
+
+	return id, nil
+}
+
+func (telegramFormatter) ValidateUrl(rawUrl string) error {
+	_, err := chatId(rawUrl)
+	return err
+}
+
+// Format builds a Bot API sendMessage body sent to the chat_id of the webhook url.
+func (telegramFormatter) Format(msg Message) (Payload, error) {
+	post := msg.Post
+
+	chatId, err := chatId(msg.Url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("<b>%s: %s</b>\nCategory: %s\n\n", html.EscapeString(headline(msg.Event)), html.EscapeString(post.Title), html.EscapeString(post.Category))
+
+	return Payload{
+		"chat_id":                  chatId,
+		"text":                     header + escapeTruncate(markdown.PlainText(post.Content), telegramMaxMessageLength-utf8.RuneCountInString(header)),
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	}, nil
+}
+
+// escapeTruncate HTML escapes s, cutting it so the escaped result is at most max
+// runes without ever splitting an entity.
+func escapeTruncate(s string, max int) string {
+	escaped := html.EscapeString(s)
+
+	if utf8.RuneCountInString(escaped) <= max {
+		return escaped
+	}
+
+	var b strings.Builder
+	n := 0
+
+	// keep one rune for the ellipsis
+	for _, r := range s {
+		e := html.EscapeString(string(r))
+		l := utf8.RuneCountInString(e)
+
+		if n+l > max-1 {
+			break
+		}
+
+		b.WriteString(e)
+		n += l
+	}
+
+	return b.String() + "…"
+}
@@ -0,0 +1,166 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/ariefzainuri96/go-logstream/internal/markdown"
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrInvalidTemplate = errors.New("invalid payload template")
+
+// MaxPayloadSize caps rendered payloads, a template like {{range 5000000}}x{{end}} would
+// render until the server runs out of memory.
+const MaxPayloadSize = 64 << 10
+
+var errPayloadTooLarge = fmt.Errorf("rendered payload is larger than %d bytes", MaxPayloadSize)
+
+// cappedBuffer fails writes once it holds more than MaxPayloadSize bytes, which stops
+// the template execution.
+type cappedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > MaxPayloadSize {
+		return 0, errPayloadTooLarge
+	}
+
+	return b.Buffer.Write(p)
+}
+
+// TemplateData is the dot value of payload templates, e.g. {{.Post.Title}} or {{.Project.Slug}}.
+// Templates are written by users and sent to third parties, so they only see public
+// fields, never e.g. the webhook secret or the members of the project.
+type TemplateData struct {
+	Event     string
+	Post      TemplatePost
+	Project   TemplateProject
+	Timestamp time.Time
+}
+
+type TemplatePost struct {
+	ID        uint
+	ProjectId uint
+	Title     string
+	Content   string
+	Category  string
+	Status    string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	PublishAt *time.Time
+}
+
+type TemplateProject struct {
+	ID   uint
+	Name string
+	Slug string
+}
+
+func newTemplateData(msg Message) TemplateData {
+	post := msg.Post
+
+	return TemplateData{
+		Event: msg.Event,
+		Post: TemplatePost{
+			ID:        post.ID,
+			ProjectId: post.ProjectId,
+			Title:     post.Title,
+			Content:   post.Content,
+			Category:  post.Category,
+			Status:    post.Status,
+			CreatedAt: post.CreatedAt,
+			UpdatedAt: post.UpdatedAt,
+			PublishAt: post.PublishAt,
+		},
+		Project: TemplateProject{
+			ID:   post.Project.ID,
+			Name: post.Project.Name,
+			Slug: post.Project.Slug,
+		},
+		Timestamp: time.Now(),
+	}
+}
+
+var templateFuncs = template.FuncMap{
+	// json renders a value as a JSON literal, so {"title": {{json .Post.Title}}} stays valid JSON
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	// markdown renders post content, e.g. {{json (markdown "text" .Post.Content)}}, as "html", "text" or "slack"
+	"markdown": func(format string, content string) (string, error) {
+		switch format {
+		case "html":
+			return markdown.ToHTML(content), nil
+		case "text":
+			return markdown.PlainText(content), nil
+		case "slack":
+			return markdown.ToSlack(content), nil
+		}
+
+		return "", fmt.Errorf("unknown markdown format %q", format)
+	},
+}
+
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("payload").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	return tmpl, nil
+}
+
+// RenderTemplate renders a payload template for msg. The result must be valid JSON
+// because webhooks are always sent as application/json, and at most MaxPayloadSize
+// bytes.
+func RenderTemplate(text string, msg Message) ([]byte, error) {
+	tmpl, err := ParseTemplate(text)
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := execute(tmpl, msg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("%w: rendered payload is not valid JSON: %s", ErrInvalidTemplate, buf.String())
+	}
+
+	return buf.Bytes(), nil
+}
+
+func execute(tmpl *template.Template, msg Message) (*cappedBuffer, error) {
+	var buf cappedBuffer
+
+	if err := tmpl.Execute(&buf, newTemplateData(msg)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	return &buf, nil
+}
+
+// ValidateTemplate is the "webhook_template" validator tag, it accepts templates that
+// parse and don't render more than MaxPayloadSize bytes for an empty post.
+func ValidateTemplate(fl validator.FieldLevel) bool {
+	tmpl, err := ParseTemplate(fl.Field().String())
+
+	if err != nil {
+		return false
+	}
+
+	_, err = execute(tmpl, Message{})
+
+	return !errors.Is(err, errPayloadTooLarge)
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ariefzainuri96/go-logstream/cmd/api/dto/entity"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	msg := Message{
+		Event: entity.WebhookEventPostPublished,
+		Post: entity.Post{
+			Title: `Say "hi"`,
+			Project: entity.Project{
+				Name:          "Acme",
+				Slug:          "acme",
+				WebhookSecret: "whsec_secret",
+				Members:       []entity.ProjectMember{{Role: entity.ProjectRoleOwner}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  error
+	}{
+		{"post and project", `{"text": {{json .Post.Title}}, "project": {{json .Project.Slug}}, "event": {{json .Event}}}`, `{"text": "Say \"hi\"", "project": "acme", "event": "post.published"}`, nil},
+		{"invalid json", `{"text": {{.Post.Title}}}`, "", ErrInvalidTemplate},
+		{"webhook secret", `{"secret": {{json .Project.WebhookSecret}}}`, "", ErrInvalidTemplate},
+		{"members", `{"members": {{json .Project.Members}}}`, "", ErrInvalidTemplate},
+		{"secret through the post", `{"secret": {{json .Post.Project.WebhookSecret}}}`, "", ErrInvalidTemplate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTemplate(tt.template, msg)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if string(got) != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateTooLarge(t *testing.T) {
+	text := `{"text": "{{range 5000000}}xxxxxxxxxx{{end}}"}`
+
+	if _, err := RenderTemplate(text, Message{}); !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("got error %v, want %v", err, ErrInvalidTemplate)
+	}
+
+	// a payload right under the cap renders
+	text = fmt.Sprintf(`{"text": "{{range %d}}x{{end}}"}`, MaxPayloadSize-len(`{"text": ""}`))
+
+	got, err := RenderTemplate(text, Message{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != MaxPayloadSize {
+		t.Fatalf("got %d bytes, want %d", len(got), MaxPayloadSize)
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	validate := validator.New()
+	validate.RegisterValidation("webhook_template", ValidateTemplate)
+
+	tests := []struct {
+		name     string
+		template string
+		valid    bool
+	}{
+		{"valid", `{"text": {{json .Post.Title}}}`, true},
+		{"not parsing", `{"text": {{json .Post.Title}`, false},
+		{"too large", `{"text": "{{range 5000000}}xxxxxxxxxx{{end}}"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Var(tt.template, "webhook_template")
+
+			if (err == nil) != tt.valid {
+				t.Fatalf("got %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+CREATE TABLE webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    post_id INTEGER REFERENCES posts(id) ON DELETE SET NULL,
+    event VARCHAR(50) NOT NULL, -- e.g., 'post.created'
+    provider VARCHAR(255) NOT NULL DEFAULT 'generic',
+    url TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'delivered', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NULL
+);
+-- Index for the worker polling due deliveries
+CREATE INDEX idx_webhook_deliveries_status_next_attempt ON webhook_deliveries(status, next_attempt_at);
\ No newline at end of file
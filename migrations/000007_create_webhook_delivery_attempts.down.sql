@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+
+DROP INDEX IF EXISTS idx_webhook_delivery_attempts_delivery_id;
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_project_id;
\ No newline at end of file
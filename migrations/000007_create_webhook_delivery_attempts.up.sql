@@ -0,0 +1,15 @@
+CREATE TABLE webhook_delivery_attempts (
+    id SERIAL PRIMARY KEY,
+    delivery_id INTEGER NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    request_payload TEXT NOT NULL,
+    response_status INTEGER NULL,
+    response_body TEXT, -- truncated snippet of the receiver response
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- Index for listing the attempts of a delivery
+CREATE INDEX idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts(delivery_id);
+-- Index for listing a project's delivery log
+CREATE INDEX idx_webhook_deliveries_project_id ON webhook_deliveries(project_id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE projects
+DROP COLUMN IF EXISTS webhook_secret;
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+ALTER TABLE projects
+ADD COLUMN webhook_secret VARCHAR(255) NULL;
+-- Existing projects get a secret too, unsigned webhooks can't be told from forged ones
+UPDATE projects SET webhook_secret = 'whsec_' || encode(gen_random_bytes(32), 'hex') WHERE webhook_secret IS NULL;
\ No newline at end of file
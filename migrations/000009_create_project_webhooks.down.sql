@@ -0,0 +1,20 @@
+ALTER TABLE projects
+ADD COLUMN webhook_url TEXT,
+ADD COLUMN webhook_provider VARCHAR(255) DEFAULT 'generic';
+
+-- Restore the first webhook of each project
+UPDATE projects
+SET webhook_url = w.url, webhook_provider = w.provider
+FROM (
+    SELECT DISTINCT ON (project_id) project_id, url, provider
+    FROM project_webhooks
+    ORDER BY project_id, id
+) w
+WHERE projects.id = w.project_id;
+
+ALTER TABLE webhook_deliveries
+DROP COLUMN IF EXISTS webhook_id;
+
+DROP TABLE IF EXISTS project_webhooks;
+
+DROP INDEX IF EXISTS idx_project_webhooks_project_id;
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE project_webhooks (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    provider VARCHAR(255) NOT NULL DEFAULT 'generic',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    events TEXT[] NOT NULL DEFAULT '{post.created}', -- e.g., 'post.created', 'post.published', 'post.updated', 'post.deleted'
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NULL
+);
+-- Index for fan out of a project's webhooks
+CREATE INDEX idx_project_webhooks_project_id ON project_webhooks(project_id);
+
+-- Move the single webhook of existing projects, keeping the old post.created only behaviour
+INSERT INTO project_webhooks (project_id, url, provider)
+SELECT id, webhook_url, COALESCE(webhook_provider, 'generic')
+FROM projects
+WHERE webhook_url IS NOT NULL AND webhook_url <> '';
+
+ALTER TABLE webhook_deliveries
+ADD COLUMN webhook_id INTEGER NULL REFERENCES project_webhooks(id) ON DELETE SET NULL;
+
+ALTER TABLE projects
+DROP COLUMN IF EXISTS webhook_url,
+DROP COLUMN IF EXISTS webhook_provider;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE project_webhooks
+DROP COLUMN IF EXISTS payload_template;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE project_webhooks
+ADD COLUMN payload_template TEXT NULL;
\ No newline at end of file
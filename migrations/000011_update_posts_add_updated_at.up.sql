@@ -0,0 +1,2 @@
+ALTER TABLE posts
+ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE posts
+ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE NULL;
+-- Index for the scheduler picking due drafts
+CREATE INDEX idx_posts_publish_at ON posts(publish_at) WHERE status = 'draft' AND publish_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE projects
+DROP COLUMN IF EXISTS allowed_origins;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE projects
+ADD COLUMN allowed_origins TEXT[] NOT NULL DEFAULT '{}'; -- e.g., 'https://app.example.com', '*'
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE projects
+DROP COLUMN IF EXISTS page_title,
+DROP COLUMN IF EXISTS logo_url,
+DROP COLUMN IF EXISTS accent_color;
\ No newline at end of file
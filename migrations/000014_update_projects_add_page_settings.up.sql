@@ -0,0 +1,4 @@
+ALTER TABLE projects
+ADD COLUMN page_title VARCHAR(255) NULL,
+ADD COLUMN logo_url TEXT NULL,
+ADD COLUMN accent_color VARCHAR(7) NULL; -- e.g., '#4f46e5'
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_project_members_user_id;
+
+DROP TABLE IF EXISTS project_members;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE project_members (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id INTEGER NULL REFERENCES users(id) ON DELETE CASCADE, -- NULL until the invited email registers
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NULL,
+    UNIQUE (project_id, email)
+);
+-- Index for the role lookup of every project request and the project list of a user
+CREATE INDEX idx_project_members_user_id ON project_members(user_id, project_id);
+
+-- The creator of existing projects becomes their owner
+INSERT INTO project_members (project_id, user_id, email, role)
+SELECT projects.id, projects.user_id, users.email, 'owner'
+FROM projects
+INNER JOIN users ON users.id = projects.user_id;
\ No newline at end of file
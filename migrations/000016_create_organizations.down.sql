@@ -0,0 +1,22 @@
+ALTER TABLE projects
+ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+
+-- Give projects back to the user of their personal org, or to the first owner of a shared org
+UPDATE projects
+SET user_id = COALESCE(
+    organizations.personal_user_id,
+    (SELECT user_id FROM org_members WHERE org_members.org_id = organizations.id AND role = 'owner' ORDER BY id LIMIT 1)
+)
+FROM organizations
+WHERE organizations.id = projects.org_id;
+
+DROP INDEX IF EXISTS idx_projects_org_id;
+
+ALTER TABLE projects
+DROP COLUMN IF EXISTS org_id;
+
+DROP INDEX IF EXISTS idx_org_members_user_id;
+
+DROP TABLE IF EXISTS org_members;
+
+DROP TABLE IF EXISTS organizations;
\ No newline at end of file
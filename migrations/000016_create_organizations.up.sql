@@ -0,0 +1,53 @@
+CREATE TABLE organizations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    billing_email VARCHAR(255) NULL,
+    personal_user_id INTEGER NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE, -- set on the personal org of a user
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE TABLE org_members (
+    id SERIAL PRIMARY KEY,
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')), -- applies to every project of the org
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NULL,
+    UNIQUE (org_id, user_id)
+);
+-- Index for the orgs of a user
+CREATE INDEX idx_org_members_user_id ON org_members(user_id, org_id);
+
+-- Every user gets a personal org owning their projects
+INSERT INTO organizations (name, personal_user_id)
+SELECT email, id
+FROM users;
+
+INSERT INTO org_members (org_id, user_id, role)
+SELECT id, personal_user_id, 'owner'
+FROM organizations
+WHERE personal_user_id IS NOT NULL;
+
+ALTER TABLE projects
+ADD COLUMN org_id INTEGER NULL REFERENCES organizations(id) ON DELETE CASCADE;
+
+UPDATE projects
+SET org_id = organizations.id
+FROM organizations
+WHERE organizations.personal_user_id = projects.user_id;
+
+-- Projects without a user (user_id is nullable) are kept in a shared org, owners can be added to it
+INSERT INTO organizations (name)
+SELECT 'Unassigned projects'
+WHERE EXISTS (SELECT 1 FROM projects WHERE org_id IS NULL);
+
+UPDATE projects
+SET org_id = (SELECT MAX(id) FROM organizations WHERE personal_user_id IS NULL)
+WHERE org_id IS NULL;
+
+ALTER TABLE projects
+ALTER COLUMN org_id SET NOT NULL,
+DROP COLUMN IF EXISTS user_id;
+
+CREATE INDEX idx_projects_org_id ON projects(org_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_project_api_keys_project_id;
+
+DROP TABLE IF EXISTS project_api_keys;
\ No newline at end of file
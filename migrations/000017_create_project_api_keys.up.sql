@@ -0,0 +1,14 @@
+CREATE TABLE project_api_keys (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(20) NOT NULL UNIQUE, -- e.g., 'ls_1a2b3c4d', shown in key lists and used to find the key
+    key_hash VARCHAR(64) NOT NULL, -- hex SHA-256 of the whole key, the key itself isn't stored
+    scopes TEXT[] NOT NULL DEFAULT '{}', -- 'posts:read', 'posts:write'
+    created_by INTEGER NULL REFERENCES users(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- Index for the key list of a project
+CREATE INDEX idx_project_api_keys_project_id ON project_api_keys(project_id);
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id VARCHAR(64) NOT NULL, -- shared by the tokens rotated from the same login
+    token_hash VARCHAR(64) NOT NULL UNIQUE, -- hex SHA-256 of the refresh token, the token itself isn't stored
+    access_jti VARCHAR(64) NOT NULL UNIQUE, -- jti of the access token issued with the refresh token
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE NULL, -- set when the token is rotated, using it again revokes the family
+    revoked_at TIMESTAMP WITH TIME ZONE NULL, -- set on logout and reuse, for the whole family
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- Index for revoking a family
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE users
+ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE NULL;
+
+-- Accounts created before verification existed keep working
+UPDATE users SET email_verified_at = created_at;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE login_attempts (
+    key VARCHAR(320) PRIMARY KEY, -- e.g., 'email:jane@example.com', 'ip:203.0.113.7'
+    failures INTEGER NOT NULL DEFAULT 0, -- failed logins since last_failure_at - window
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE NULL
+);
+-- Index for pruning old attempts
+CREATE INDEX idx_login_attempts_last_failure_at ON login_attempts(last_failure_at);
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE users
+ADD COLUMN totp_secret VARCHAR(64) NULL, -- base32, set on enrollment, active once totp_enabled_at is set
+ADD COLUMN totp_enabled_at TIMESTAMP WITH TIME ZONE NULL,
+ADD COLUMN totp_last_step BIGINT NULL; -- time step of the last accepted code, a code can't be replayed
+
+CREATE TABLE user_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL, -- hex SHA-256 of the code, the code itself isn't stored
+    used_at TIMESTAMP WITH TIME ZONE NULL, -- codes work once
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, code_hash)
+);
\ No newline at end of file
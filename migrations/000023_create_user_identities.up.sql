@@ -0,0 +1,12 @@
+CREATE TABLE user_identities (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(64) NOT NULL, -- name of the OIDC provider in the env config, e.g., 'okta'
+    subject VARCHAR(255) NOT NULL, -- sub claim of the ID token, stable per provider
+    email VARCHAR(255) NOT NULL, -- email claim of the last login
+    last_login_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject)
+);
+-- Index for the identities of a user
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
\ No newline at end of file
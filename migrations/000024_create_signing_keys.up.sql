@@ -0,0 +1,9 @@
+CREATE TABLE signing_keys (
+    kid VARCHAR(64) PRIMARY KEY, -- kid header of the JWTs the key signs
+    algorithm VARCHAR(16) NOT NULL, -- 'RS256' or 'EdDSA'
+    private_key BYTEA NOT NULL, -- PKCS #8, AES-GCM encrypted with a key derived from SECRET_KEY
+    activates_at TIMESTAMP WITH TIME ZONE NOT NULL, -- the key signs from then on, until the next key activates
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- Index for loading the keys in rotation order
+CREATE INDEX idx_signing_keys_activates_at ON signing_keys(activates_at);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_posts_project_published_at;
+ALTER TABLE posts
+DROP COLUMN IF EXISTS published_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE posts
+ADD COLUMN published_at TIMESTAMP WITH TIME ZONE NULL;
+-- Scheduled posts kept their publish_at, the others were published when created
+UPDATE posts SET published_at = COALESCE(publish_at, created_at) WHERE status = 'published';
+-- Index for public listings and feeds, newest published first
+CREATE INDEX idx_posts_project_published_at ON posts(project_id, published_at DESC) WHERE status = 'published';
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Emails are looked up whatever their case, two users mustn't differ only by it.
+-- Fails while such users exist, merge or rename them first.
+CREATE UNIQUE INDEX idx_users_email_lower ON users(LOWER(email));
\ No newline at end of file
@@ -0,0 +1,113 @@
+// Package webhooksig signs and verifies LogStream webhook requests.
+//
+// Every webhook sent for a project with a signing secret carries two headers:
+//
+//	X-LogStream-Timestamp: 1700000000
+//	X-LogStream-Signature: sha256=<hex HMAC-SHA256 of "<timestamp>.<body>">
+//
+// Receivers can verify a request with:
+//
+//	if err := webhooksig.VerifyRequest(r, secret, webhooksig.DefaultTolerance); err != nil {
+//		http.Error(w, "invalid signature", http.StatusUnauthorized)
+//		return
+//	}
+package webhooksig
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-LogStream-Signature"
+	TimestampHeader = "X-LogStream-Timestamp"
+
+	// DefaultTolerance is the maximum accepted age of a signed request, which
+	// limits how long a captured request can be replayed.
+	DefaultTolerance = 5 * time.Minute
+
+	signaturePrefix = "sha256="
+	secretPrefix    = "whsec_"
+)
+
+var (
+	ErrMissingSignature = errors.New("webhooksig: missing signature or timestamp header")
+	ErrInvalidTimestamp = errors.New("webhooksig: invalid timestamp")
+	ErrExpired          = errors.New("webhooksig: timestamp outside tolerance")
+	ErrInvalidSignature = errors.New("webhooksig: signature mismatch")
+)
+
+// NewSecret returns a random signing secret.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// Sign returns the X-LogStream-Signature value for body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature and timestamp header values against body. A tolerance of
+// zero disables the timestamp age check.
+func Verify(secret string, signature string, timestamp string, body []byte, tolerance time.Duration) error {
+	if signature == "" || timestamp == "" {
+		return ErrMissingSignature
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	if tolerance > 0 {
+		age := time.Since(time.Unix(ts, 0))
+
+		if age > tolerance || age < -tolerance {
+			return ErrExpired
+		}
+	}
+
+	expected := Sign(secret, ts, body)
+
+	if !strings.HasPrefix(signature, signaturePrefix) || !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// VerifyRequest verifies an incoming webhook request. The body is read and put back,
+// so handlers can still decode it afterwards.
+func VerifyRequest(r *http.Request, secret string, tolerance time.Duration) error {
+	body, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		return err
+	}
+
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return Verify(secret, r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), body, tolerance)
+}
@@ -0,0 +1,110 @@
+package webhooksig
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSecret(t *testing.T) {
+	a, err := NewSecret()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := NewSecret()
+
+	if !strings.HasPrefix(a, secretPrefix) || len(a) != len(secretPrefix)+64 {
+		t.Fatalf("unexpected secret format %q", a)
+	}
+
+	if a == b {
+		t.Fatal("two secrets are equal")
+	}
+}
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256("whsec_test", "1700000000.{}")
+	const want = "sha256=35495024f4ef3f94e5a93e22221544c4b75e9a42300cd965ab81cb85cd994e91"
+
+	got := Sign("whsec_test", 1700000000, []byte("{}"))
+
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	for name, other := range map[string]string{
+		"secret":    Sign("whsec_other", 1700000000, []byte("{}")),
+		"timestamp": Sign("whsec_test", 1700000001, []byte("{}")),
+		"body":      Sign("whsec_test", 1700000000, []byte("{ }")),
+	} {
+		if other == got {
+			t.Errorf("changing the %s doesn't change the signature", name)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"event":"post.published"}`)
+	now := time.Now().Unix()
+	ts := strconv.FormatInt(now, 10)
+	old := strconv.FormatInt(now-int64((10*time.Minute).Seconds()), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		tolerance time.Duration
+		want      error
+	}{
+		{"valid", secret, Sign(secret, now, body), ts, body, DefaultTolerance, nil},
+		{"missing signature", secret, "", ts, body, DefaultTolerance, ErrMissingSignature},
+		{"missing timestamp", secret, Sign(secret, now, body), "", body, DefaultTolerance, ErrMissingSignature},
+		{"invalid timestamp", secret, Sign(secret, now, body), "yesterday", body, DefaultTolerance, ErrInvalidTimestamp},
+		{"expired", secret, Sign(secret, now-600, body), old, body, DefaultTolerance, ErrExpired},
+		{"expired without tolerance", secret, Sign(secret, now-600, body), old, body, 0, nil},
+		{"wrong secret", "whsec_other", Sign(secret, now, body), ts, body, DefaultTolerance, ErrInvalidSignature},
+		{"tampered body", secret, Sign(secret, now, body), ts, []byte(`{"event":"post.deleted"}`), DefaultTolerance, ErrInvalidSignature},
+		{"missing prefix", secret, strings.TrimPrefix(Sign(secret, now, body), signaturePrefix), ts, body, DefaultTolerance, ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.signature, tt.timestamp, tt.body, tt.tolerance)
+
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"event":"post.published"}`)
+	now := time.Now().Unix()
+
+	r := httptest.NewRequest("POST", "/hook", bytes.NewReader(body))
+	r.Header.Set(SignatureHeader, Sign(secret, now, body))
+	r.Header.Set(TimestampHeader, strconv.FormatInt(now, 10))
+
+	if err := VerifyRequest(r, secret, DefaultTolerance); err != nil {
+		t.Fatal(err)
+	}
+
+	// the body is still readable by the handler
+	rest, _ := io.ReadAll(r.Body)
+
+	if !bytes.Equal(rest, body) {
+		t.Fatalf("body %q not restored", rest)
+	}
+}